@@ -1,9 +1,18 @@
 package godog
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	godog_http "github.com/SKF/go-tests-utility/api/godog/http"
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+	"github.com/SKF/go-tests-utility/api/godog/openapi"
 )
 
 type BaseFeature struct {
@@ -12,26 +21,134 @@ type BaseFeature struct {
 	Request   Request
 	baseURL   string
 
-	GetValue func(key string) (value string, err error)
+	ctx         context.Context
+	fixturesDir string
+	client      *http.Client
+	contract    *openapi.Contract
+
+	equalOptions []json_matcher.EqualOption
+	soft         softAssertions
+
+	scenario        *godog.Scenario
+	requestDump     *requestDump
+	dumpedRequests  int
+	history         []Exchange
+	historySize     *int
+	aliases         map[string]Exchange
+	snapshotsDir    string
+	snapshotOptions []json_matcher.SnapshotOption
+
+	// usedExchange is the saved exchange the current step asserted on, see
+	// WithExchange
+	usedExchange *Exchange
+
+	// Variables are looked up before GetValue when resolving `.` values
+	Variables Variables
+	GetValue  func(key string) (value string, err error)
 }
 
 func (api *BaseFeature) SetBaseUrl(baseUrl string) {
 	api.baseURL = baseUrl
 }
 
+// SetContext sets the context used by the methods and steps that don't take
+// one, e.g. the context returned by tracecontext.New so that requests and
+// retry attempts are traced as part of the step executing them.
+func (api *BaseFeature) SetContext(ctx context.Context) {
+	api.ctx = ctx
+}
+
+func (api *BaseFeature) context() context.Context {
+	if api.ctx == nil {
+		return context.Background()
+	}
+
+	return api.ctx
+}
+
+// SetHTTPClient sets the client used to execute requests, see
+// godog_http.NewClient for creating one with timeouts, transports and TLS
+// settings. The shared godog_http.DefaultClient is used when none is set.
+func (api *BaseFeature) SetHTTPClient(client *http.Client) {
+	api.client = client
+}
+
+// SetContract makes every executed request and its response be validated
+// against the OpenAPI document of contract, see openapi.Load. Retried
+// requests are validated once the condition is met.
+func (api *BaseFeature) SetContract(contract *openapi.Contract) {
+	api.contract = contract
+}
+
+func (api *BaseFeature) httpClient() *http.Client {
+	if api.client == nil {
+		return godog_http.DefaultClient()
+	}
+
+	return api.client
+}
+
 type Request struct {
 	Url           string
+	Query         url.Values
 	Body          map[string]interface{}
+	BodyMode      BodyMode
+	Form          url.Values
+	Files         []FormFile
+	RawBody       []byte
 	Headers       http.Header
 	Method        string
 	ExecutionTime time.Time
+
+	// payload is the body sent by the last execution
+	payload []byte
+	// contentType is the Content-Type of the body mode of payload
+	contentType string
 }
 
 func (r *Request) String() string {
-	return fmt.Sprintf("%s :: %s headers: %s time: %v\n %s", r.Method, r.Url, r.Headers, r.ExecutionTime, r.Body)
+	return fmt.Sprintf("%s :: %s headers: %s time: %v\n %s", r.Method, r.displayURL(), r.Headers, r.ExecutionTime, r.Body)
+}
+
+// ResolvedURL returns Url with the Query parameters added to any query
+// already present in it.
+func (r *Request) ResolvedURL() (string, error) {
+	if len(r.Query) == 0 {
+		return r.Url, nil
+	}
+
+	u, err := url.Parse(r.Url)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid request url: %s", r.Url)
+	}
+
+	query := u.Query()
+
+	for key, values := range r.Query {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// displayURL returns the resolved url for logs and renderings, or Url when it
+// can't be resolved.
+func (r *Request) displayURL() string {
+	resolved, err := r.ResolvedURL()
+	if err != nil {
+		return r.Url
+	}
+
+	return resolved
 }
 
 type response struct {
 	Body []byte
 	Raw  *http.Response
+	// Duration is how long it took to receive the response
+	Duration time.Duration
 }
@@ -0,0 +1,176 @@
+package godog
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var bodyPathIndexRegexp = regexp.MustCompile(`\[(\d+)]`)
+
+// maxBodyPathIndex bounds the array indices of body paths since arrays are
+// padded with nulls up to the index.
+const maxBodyPathIndex = 1000
+
+type bodyPathSegment struct {
+	key   string
+	index int
+}
+
+func (s bodyPathSegment) isIndex() bool {
+	return s.index >= 0
+}
+
+// parseBodyPath splits a path such as `items[0].name` into its keys and
+// array indices.
+func parseBodyPath(path string) ([]bodyPathSegment, error) {
+	var segments []bodyPathSegment
+
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		if idx := strings.Index(part, "["); idx >= 0 {
+			key = part[:idx]
+		}
+
+		if key != "" {
+			segments = append(segments, bodyPathSegment{key: key, index: -1})
+		}
+
+		indices := part[len(key):]
+		if bodyPathIndexRegexp.ReplaceAllString(indices, "") != "" {
+			return nil, errors.Errorf("invalid body path: '%s'", path)
+		}
+
+		matches := bodyPathIndexRegexp.FindAllStringSubmatch(indices, -1)
+		for _, match := range matches {
+			index, err := strconv.Atoi(match[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid array index in body path: '%s'", path)
+			}
+
+			if index > maxBodyPathIndex {
+				return nil, errors.Errorf("array index %d in body path '%s' exceeds %d", index, path, maxBodyPathIndex)
+			}
+
+			segments = append(segments, bodyPathSegment{index: index})
+		}
+
+		if key == "" && len(matches) == 0 {
+			return nil, errors.Errorf("invalid body path: '%s'", path)
+		}
+	}
+
+	if len(segments) == 0 || segments[0].isIndex() {
+		return nil, errors.Errorf("body path must start with a key: '%s'", path)
+	}
+
+	return segments, nil
+}
+
+// setBodyParameter sets the value at path in the request body, creating
+// intermediate objects and arrays as needed.
+func (api *BaseFeature) setBodyParameter(path string, value interface{}) error {
+	segments, err := parseBodyPath(path)
+	if err != nil {
+		return err
+	}
+
+	if api.Request.Body == nil {
+		api.Request.Body = make(map[string]interface{})
+	}
+
+	_, err = setBodyValue(api.Request.Body, segments, value, path)
+
+	return err
+}
+
+func setBodyValue(container interface{}, segments []bodyPathSegment, value interface{}, path string) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+
+	segment := segments[0]
+
+	if segment.isIndex() {
+		list, ok := toList(container)
+		if !ok {
+			return nil, errors.Errorf("body path '%s': expected an array at index %d, found %T", path, segment.index, container)
+		}
+
+		for len(list) <= segment.index {
+			list = append(list, nil)
+		}
+
+		child, err := setBodyValue(list[segment.index], segments[1:], value, path)
+		if err != nil {
+			return nil, err
+		}
+
+		list[segment.index] = child
+
+		return list, nil
+	}
+
+	if container == nil {
+		container = make(map[string]interface{})
+	}
+
+	object, ok := container.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("body path '%s': expected an object at key '%s', found %T", path, segment.key, container)
+	}
+
+	child, err := setBodyValue(object[segment.key], segments[1:], value, path)
+	if err != nil {
+		return nil, err
+	}
+
+	object[segment.key] = child
+
+	return object, nil
+}
+
+func toList(container interface{}) ([]interface{}, bool) {
+	switch container := container.(type) {
+	case nil:
+		return []interface{}{}, true
+	case []interface{}:
+		return container, true
+	}
+
+	value := reflect.ValueOf(container)
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	list := make([]interface{}, value.Len())
+	for i := range list {
+		list[i] = value.Index(i).Interface()
+	}
+
+	return list, true
+}
+
+// parseBodyLiteral parses value as a JSON literal such as `true`, `null`,
+// `42`, `"text"` or `{"key": [1, 2]}`. Values that aren't valid JSON are
+// used as plain strings.
+func parseBodyLiteral(value string) interface{} {
+	if !json.Valid([]byte(value)) {
+		return value
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(value))
+	decoder.UseNumber()
+
+	var literal interface{}
+	if err := decoder.Decode(&literal); err != nil {
+		return value
+	}
+
+	return literal
+}
@@ -0,0 +1,76 @@
+package godog
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBodyPath(t *testing.T) {
+	segments, err := parseBodyPath("items[0][1].name")
+	require.NoError(t, err)
+	assert.Equal(t, []bodyPathSegment{
+		{key: "items", index: -1},
+		{index: 0},
+		{index: 1},
+		{key: "name", index: -1},
+	}, segments)
+
+	for _, path := range []string{"", "[0]", "a..b", "a[x]", "a[0]b", "a[0", "a[1001]", "a[99999999999999999999]"} {
+		_, err = parseBodyPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestBaseFeature_SetRequestBodyParameterToValue(t *testing.T) {
+	api := BaseFeature{}
+	api.Variables.Set("count", float64(2))
+
+	err := api.CreatePathRequest(http.MethodPost, "")
+	require.NoError(t, err)
+
+	require.NoError(t, api.SetRequestBodyParameterToValue("enabled", "true"))
+	require.NoError(t, api.SetRequestBodyParameterToValue("parent", "null"))
+	require.NoError(t, api.SetRequestBodyParameterToValue("limit", "42"))
+	require.NoError(t, api.SetRequestBodyParameterToValue("count", ".count"))
+	require.NoError(t, api.SetRequestBodyParameterToValue("label", "plain text"))
+	require.NoError(t, api.SetRequestBodyParameterToValue("quoted", `"true"`))
+	require.NoError(t, api.SetRequestBodyParameterToValue("items", `[{"name": "a"}]`))
+	require.NoError(t, api.SetRequestBodyParameterToValue("items[0].tags[1]", `"x"`))
+	require.NoError(t, api.SetRequestBodyParameterToValue("items[1].name", `"b"`))
+	require.NoError(t, api.SetRequestBodyParameterTo("nested.list[2]", "c"))
+
+	jsonBody, err := json.Marshal(api.Request.Body)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"enabled": true,
+		"parent": null,
+		"limit": 42,
+		"count": 2,
+		"label": "plain text",
+		"quoted": "true",
+		"items": [{"name": "a", "tags": [null, "x"]}, {"name": "b"}],
+		"nested": {"list": [null, null, "c"]}
+	}`, string(jsonBody))
+}
+
+func TestBaseFeature_SetRequestBodyParameter_NonContainer(t *testing.T) {
+	api := BaseFeature{}
+
+	err := api.CreatePathRequest(http.MethodPost, "")
+	require.NoError(t, err)
+
+	require.NoError(t, api.SetRequestBodyParameterTo("name", "value"))
+	require.NoError(t, api.SetRequestBodyStringListParameterTo("list", "a, b"))
+
+	assert.Error(t, api.SetRequestBodyParameterTo("name.first", "value"))
+	assert.Error(t, api.SetRequestBodyParameterToInt("name[0]", 1))
+	assert.Error(t, api.SetRequestBodyParameterToFloat("list.key", 1.5))
+
+	require.NoError(t, api.SetRequestBodyParameterTo("list[1]", "c"))
+	assert.Equal(t, []interface{}{"a", "c"}, api.Request.Body["list"])
+}
@@ -0,0 +1,211 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const redacted = "REDACTED"
+
+// Interaction is a recorded request and the response it got.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is stored as JSON when it is valid JSON, to keep cassettes readable
+// and diffable, as a string when it is UTF-8 and base64 encoded otherwise.
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) == 0 {
+		return []byte(`""`), nil
+	}
+
+	if json.Valid(b) {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, b); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(map[string]json.RawMessage{"json": compacted.Bytes()})
+	}
+
+	if !utf8.Valid(b) {
+		return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+	}
+
+	return json.Marshal(string(b))
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+
+	var wrapped struct {
+		JSON   json.RawMessage `json:"json"`
+		Base64 *string         `json:"base64"`
+	}
+
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return err
+	}
+
+	if wrapped.Base64 != nil {
+		decoded, err := base64.StdEncoding.DecodeString(*wrapped.Base64)
+		if err != nil {
+			return errors.Wrap(err, "invalid base64 body")
+		}
+
+		*b = decoded
+
+		return nil
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, wrapped.JSON); err != nil {
+		return err
+	}
+
+	*b = compacted.Bytes()
+
+	return nil
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+func Load(filename string) (*Cassette, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read cassette: %s", filename)
+	}
+
+	var c Cassette
+	if err = json.Unmarshal(content, &c); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse cassette: %s", filename)
+	}
+
+	return &c, nil
+}
+
+func (c *Cassette) Save(filename string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "json.MarshalIndent failed")
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return errors.Wrapf(err, "failed to create cassette directory for: %s", filename)
+	}
+
+	return os.WriteFile(filename, append(content, '\n'), 0600)
+}
+
+func newRequest(req *http.Request, redactedHeaders []string) (Request, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return Request{}, errors.Wrap(err, "failed to read request body")
+		}
+
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return Request{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: redactHeaders(req.Header, redactedHeaders),
+		Body:    body,
+	}, nil
+}
+
+func newResponse(resp *http.Response) (Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, errors.Wrap(err, "failed to read response body")
+	}
+
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return Response{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header.Clone(),
+		Body:       body,
+	}, nil
+}
+
+func (r Response) httpResponse(req *http.Request) *http.Response {
+	// JSON bodies are compacted when loaded, so the recorded length is stale
+	header := r.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	header.Del("Content-Length")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+func redactHeaders(header http.Header, names []string) http.Header {
+	header = header.Clone()
+
+	for _, name := range names {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+
+	return header
+}
+
+var unsafeFilenameRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Filename returns a file system friendly name for a scenario name.
+func Filename(name string) string {
+	name = unsafeFilenameRegexp.ReplaceAllString(name, "_")
+	return strings.Trim(name, "_") + ".json"
+}
@@ -0,0 +1,322 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/SKF/go-utility/v2/log"
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	"github.com/SKF/go-tests-utility/api/godog/internal/scenario"
+)
+
+const (
+	EnvMode = "CASSETTE_MODE"
+)
+
+// Mode decides whether a Recorder passes requests through, records them or
+// replays previously recorded responses.
+type Mode int
+
+const (
+	ModeDisabled Mode = iota
+	ModeRecord
+	ModeReplay
+)
+
+// ModeFromEnv reads the mode from CASSETTE_MODE, `record` or `replay`, any
+// other value disables the recorder.
+func ModeFromEnv() Mode {
+	switch strings.ToLower(os.Getenv(EnvMode)) {
+	case "record":
+		return ModeRecord
+	case "replay":
+		return ModeReplay
+	}
+
+	return ModeDisabled
+}
+
+// Matcher reports whether an incoming request matches a recorded one.
+type Matcher func(recorded, actual Request) bool
+
+func MatchMethod(recorded, actual Request) bool {
+	return recorded.Method == actual.Method
+}
+
+func MatchURL(recorded, actual Request) bool {
+	return recorded.URL == actual.URL
+}
+
+// MatchBody compares bodies, ignoring formatting when both are JSON.
+func MatchBody(recorded, actual Request) bool {
+	if json.Valid(recorded.Body) && json.Valid(actual.Body) {
+		var a, b bytes.Buffer
+
+		_ = json.Compact(&a, recorded.Body)
+		_ = json.Compact(&b, actual.Body)
+
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}
+
+	return bytes.Equal(recorded.Body, actual.Body)
+}
+
+// MatchHeaders compares the values of the named headers.
+func MatchHeaders(names ...string) Matcher {
+	return func(recorded, actual Request) bool {
+		for _, name := range names {
+			if strings.Join(recorded.Headers.Values(name), ",") != strings.Join(actual.Headers.Values(name), ",") {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to send requests in record and
+// disabled mode, http.DefaultTransport is used by default.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatchers replaces the default method and URL matchers used in replay
+// mode, all matchers have to match.
+func WithMatchers(matchers ...Matcher) Option {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// WithRedactedHeaders sets the request headers that are stored as REDACTED,
+// by default the Authorization header.
+func WithRedactedHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		r.redactedHeaders = names
+	}
+}
+
+// ErrCassetteActive is returned by Start while the cassette of another
+// scenario is active.
+var ErrCassetteActive = errors.New("cassette: another cassette is active, use a Recorder per scenario when scenarios run concurrently")
+
+// Recorder is an http.RoundTripper that records or replays the requests of
+// a scenario to a cassette file in dir. Use it as the transport of the client
+// given to BaseFeature and HttpClient. A Recorder holds one cassette at a
+// time, so create one per scenario, e.g. in the scenario initializer, when
+// scenarios run concurrently.
+type Recorder struct {
+	dir             string
+	mode            Mode
+	transport       http.RoundTripper
+	matchers        []Matcher
+	redactedHeaders []string
+
+	lock     sync.Mutex
+	active   bool
+	filename string
+	cassette *Cassette
+	replayed []bool
+	started  map[string]int
+	startErr error
+}
+
+func New(dir string, mode Mode, opts ...Option) *Recorder {
+	r := &Recorder{
+		dir:             dir,
+		mode:            mode,
+		transport:       http.DefaultTransport,
+		matchers:        []Matcher{MatchMethod, MatchURL},
+		redactedHeaders: []string{"Authorization"},
+		started:         make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Start begins recording or replaying the cassette of name, it fails with
+// ErrCassetteActive until the previous cassette is stopped. Starting the
+// same name more than once uses a numbered cassette for every start after
+// the first.
+func (r *Recorder) Start(name string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.active {
+		return errors.Wrapf(ErrCassetteActive, "starting %s while %s is active", name, r.filename)
+	}
+
+	r.active = true
+
+	if count := r.started[name]; count > 0 {
+		r.started[name]++
+		name = fmt.Sprintf("%s_%d", name, count+1)
+	} else {
+		r.started[name] = 1
+	}
+
+	r.filename = filepath.Join(r.dir, Filename(name))
+	r.cassette = &Cassette{}
+	r.replayed = nil
+	r.startErr = nil
+
+	if r.mode != ModeReplay {
+		return nil
+	}
+
+	cassette, err := Load(r.filename)
+	if err != nil {
+		r.cassette = nil
+		r.startErr = err
+
+		return err
+	}
+
+	r.cassette = cassette
+	r.replayed = make([]bool, len(cassette.Interactions))
+
+	return nil
+}
+
+// Stop saves the cassette when recording.
+func (r *Recorder) Stop() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.active = false
+
+	if r.mode != ModeRecord || r.cassette == nil {
+		return nil
+	}
+
+	err := r.cassette.Save(r.filename)
+	r.cassette = nil
+
+	return err
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeRecord:
+		return r.record(req)
+	case ModeReplay:
+		return r.replay(req)
+	}
+
+	return r.transport.RoundTrip(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	request, err := newRequest(req, r.redactedHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := newResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cassette == nil {
+		return nil, errors.New("cassette: recording hasn't been started")
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: request, Response: response})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	request, err := newRequest(req, r.redactedHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cassette == nil {
+		if r.startErr != nil {
+			return nil, r.startErr
+		}
+
+		return nil, errors.New("cassette: replay hasn't been started")
+	}
+
+	for idx, interaction := range r.cassette.Interactions {
+		if r.replayed[idx] || !r.matches(interaction.Request, request) {
+			continue
+		}
+
+		r.replayed[idx] = true
+
+		return interaction.Response.httpResponse(req), nil
+	}
+
+	return nil, errors.Errorf("cassette: no recorded interaction for %s %s in %s", request.Method, request.URL, r.filename)
+}
+
+func (r *Recorder) matches(recorded, actual Request) bool {
+	for _, match := range r.matchers {
+		if !match(recorded, actual) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type GodogScenarioContext interface {
+	BeforeScenario(func(*godog.Scenario))
+	AfterScenario(func(*godog.Scenario, error))
+}
+
+// RegisterHooks starts a cassette named after the feature file and scenario
+// before every scenario and stops it afterwards. Errors when starting, such
+// as a missing cassette in replay mode, are reported by the first request.
+func (r *Recorder) RegisterHooks(sc GodogScenarioContext) {
+	sc.BeforeScenario(func(s *godog.Scenario) {
+		err := r.Start(scenario.Feature(s) + "_" + scenario.Name(s))
+
+		switch {
+		case errors.Is(err, ErrCassetteActive):
+			log.Errorf("Failed to start cassette: %s", err)
+		case err != nil:
+			log.Debugf("Failed to start cassette: %s", err)
+		}
+	})
+
+	sc.AfterScenario(func(s *godog.Scenario, _ error) {
+		if err := r.Stop(); err != nil {
+			log.Errorf("Failed to save cassette: %s", err)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package cassette_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+	"github.com/SKF/go-tests-utility/api/godog/cassette"
+	godog_http "github.com/SKF/go-tests-utility/api/godog/http"
+)
+
+type testScenarioContext struct {
+	callbackBeforeScenario func(*godog.Scenario)
+	callbackAfterScenario  func(*godog.Scenario, error)
+}
+
+func (sc *testScenarioContext) BeforeScenario(fn func(*godog.Scenario)) {
+	sc.callbackBeforeScenario = fn
+}
+
+func (sc *testScenarioContext) AfterScenario(fn func(*godog.Scenario, error)) {
+	sc.callbackAfterScenario = fn
+}
+
+func newFeature(t *testing.T, recorder *cassette.Recorder, baseURL string) *api_godog.BaseFeature {
+	t.Helper()
+
+	client, err := godog_http.NewClient(godog_http.WithTransport(recorder))
+	require.NoError(t, err)
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(baseURL)
+	api.SetHTTPClient(client)
+
+	return api
+}
+
+func executeScenario(t *testing.T, api *api_godog.BaseFeature) {
+	t.Helper()
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/nodes"))
+	require.NoError(t, api.SetRequestHeaderParameterTo("Authorization", "secret"))
+	require.NoError(t, api.SetRequestBodyParameterTo("label", "Pump 1"))
+	require.NoError(t, api.ExecuteTheRequest())
+	require.NoError(t, api.AssertResponseCode(http.StatusCreated))
+	require.NoError(t, api.AssertResponseBodyValueEquals(".data.count", "1"))
+
+	require.NoError(t, api.ExecuteTheRequest())
+	require.NoError(t, api.AssertResponseBodyValueEquals(".data.count", "2"))
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"data": {"count": %d}}`, count)
+	}))
+
+	dir := t.TempDir()
+	scenario := &godog.Scenario{Uri: "features/nodes.feature", Name: "create a node"}
+
+	sc := &testScenarioContext{}
+	recorder := cassette.New(dir, cassette.ModeRecord)
+	recorder.RegisterHooks(sc)
+
+	sc.callbackBeforeScenario(scenario)
+	executeScenario(t, newFeature(t, recorder, s.URL))
+	sc.callbackAfterScenario(scenario, nil)
+	s.Close()
+
+	filename := filepath.Join(dir, "nodes_create_a_node.json")
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "secret")
+
+	recorded, err := cassette.Load(filename)
+	require.NoError(t, err)
+	require.Len(t, recorded.Interactions, 2)
+	assert.JSONEq(t, `{"label": "Pump 1"}`, string(recorded.Interactions[0].Request.Body))
+
+	sc = &testScenarioContext{}
+	recorder = cassette.New(dir, cassette.ModeReplay, cassette.WithMatchers(
+		cassette.MatchMethod, cassette.MatchURL, cassette.MatchBody, cassette.MatchHeaders("Authorization"),
+	))
+	recorder.RegisterHooks(sc)
+
+	sc.callbackBeforeScenario(scenario)
+	api := newFeature(t, recorder, s.URL)
+	executeScenario(t, api)
+
+	require.Error(t, api.ExecuteTheRequest(), "every recorded interaction is only replayed once")
+	sc.callbackAfterScenario(scenario, nil)
+}
+
+func TestRecorder_ReplayMismatch(t *testing.T) {
+	dir := t.TempDir()
+
+	c := cassette.Cassette{
+		Interactions: []cassette.Interaction{{
+			Request:  cassette.Request{Method: http.MethodGet, URL: "http://localhost/nodes"},
+			Response: cassette.Response{StatusCode: http.StatusOK, Body: cassette.Body("plain text")},
+		}},
+	}
+	require.NoError(t, c.Save(filepath.Join(dir, cassette.Filename("scenario"))))
+
+	recorder := cassette.New(dir, cassette.ModeReplay)
+	require.NoError(t, recorder.Start("scenario"))
+
+	client := &http.Client{Transport: recorder}
+
+	resp, err := client.Post("http://localhost/nodes", "text/plain", nil)
+	require.Error(t, err)
+	assert.Nil(t, resp)
+
+	resp, err = client.Get("http://localhost/nodes")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "plain text", string(body))
+
+	require.NoError(t, recorder.Stop())
+	require.Error(t, recorder.Start("missing"))
+}
+
+func TestRecorder_OneCassetteAtATime(t *testing.T) {
+	recorder := cassette.New(t.TempDir(), cassette.ModeRecord)
+
+	require.NoError(t, recorder.Start("first"))
+	require.ErrorIs(t, recorder.Start("second"), cassette.ErrCassetteActive)
+
+	require.NoError(t, recorder.Stop())
+	require.NoError(t, recorder.Start("second"))
+}
+
+func TestBody_Binary(t *testing.T) {
+	body := cassette.Body{0xff, 0xfe, 0x00, 'a'}
+
+	encoded, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"base64": "//4AYQ=="}`, string(encoded))
+
+	var decoded cassette.Body
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, body, decoded)
+
+	encoded, err = json.Marshal(cassette.Body("plain text"))
+	require.NoError(t, err)
+	assert.Equal(t, `"plain text"`, string(encoded))
+}
+
+func TestRecorder_Disabled(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	dir := t.TempDir()
+	recorder := cassette.New(dir, cassette.ModeFromEnv())
+	require.NoError(t, recorder.Start("scenario"))
+
+	resp, err := (&http.Client{Transport: recorder}).Get(s.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.NoError(t, recorder.Stop())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestRecorder_OutlineRows(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, label := range []string{"Pump 1", "Pump 2"} {
+		scenario := &godog.Scenario{
+			Uri:        "features/nodes.feature",
+			Name:       "create a node",
+			AstNodeIds: []string{"1", "2"},
+			Steps:      []*godog.Step{{Text: `I set the request body parameter "label" to "` + label + `"`}},
+		}
+
+		sc := &testScenarioContext{}
+		recorder := cassette.New(dir, cassette.ModeRecord)
+		recorder.RegisterHooks(sc)
+
+		sc.callbackBeforeScenario(scenario)
+		sc.callbackAfterScenario(scenario, nil)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "nodes_create_a_node_*.json"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
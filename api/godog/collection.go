@@ -0,0 +1,126 @@
+package godog
+
+import (
+	"fmt"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// elementPhrases maps the phrasing of the element steps to operators, e.g.
+// `some element of ".data" should have ".name" equal to "Pump 1"`.
+var elementPhrases = map[string]json_matcher.Operator{
+	"equal to":      json_matcher.OpEqual,
+	"not equal to":  json_matcher.OpNotEqual,
+	"greater than":  json_matcher.OpGreater,
+	"at least":      json_matcher.OpGreaterOrEqual,
+	"less than":     json_matcher.OpLess,
+	"at most":       json_matcher.OpLessOrEqual,
+	"containing":    json_matcher.OpContains,
+	"starting with": json_matcher.OpHasPrefix,
+	"ending with":   json_matcher.OpHasSuffix,
+	"matching":      json_matcher.OpMatches,
+	"before":        json_matcher.OpBefore,
+	"after":         json_matcher.OpAfter,
+}
+
+const elementPhrasesExpr = `(equal to|not equal to|greater than|at least|less than|at most|containing|starting with|ending with|matching|before|after)`
+
+func (api *BaseFeature) AssertResponseAnyMatch(arrayPath string, predicate json_matcher.Predicate) error {
+	_, err := json_matcher.AnyMatch(api.Response.Body, arrayPath, predicate)
+	return err
+}
+
+func (api *BaseFeature) AssertResponseAllMatch(arrayPath string, predicate json_matcher.Predicate) error {
+	return json_matcher.AllMatch(api.Response.Body, arrayPath, predicate)
+}
+
+func (api *BaseFeature) AssertResponseNoneMatch(arrayPath string, predicate json_matcher.Predicate) error {
+	return json_matcher.NoneMatch(api.Response.Body, arrayPath, predicate)
+}
+
+// AssertResponseContains checks that the array at arrayPath has an element
+// containing subset, which is rendered as a template first.
+func (api *BaseFeature) AssertResponseContains(arrayPath, subset string) error {
+	rendered, err := api.renderTemplate(subset)
+	if err != nil {
+		return err
+	}
+
+	_, err = json_matcher.Contains(api.Response.Body, arrayPath, rendered)
+
+	return err
+}
+
+func (api *BaseFeature) AssertResponseContainsDocString(arrayPath string, subset *godog.DocString) error {
+	return api.AssertResponseContains(arrayPath, subset.Content)
+}
+
+// SaveResponseMatchIndexAs saves the index of the first element of the
+// array at arrayPath that satisfies predicate as the variable name.
+func (api *BaseFeature) SaveResponseMatchIndexAs(arrayPath string, predicate json_matcher.Predicate, name string) error {
+	index, err := json_matcher.AnyMatch(api.Response.Body, arrayPath, predicate)
+	if err != nil {
+		return err
+	}
+
+	api.Variables.Set(name, index)
+
+	return nil
+}
+
+// SaveResponseMatchValueAs saves the first element of the array at
+// arrayPath that satisfies predicate as the variable name.
+func (api *BaseFeature) SaveResponseMatchValueAs(arrayPath string, predicate json_matcher.Predicate, name string) error {
+	index, err := json_matcher.AnyMatch(api.Response.Body, arrayPath, predicate)
+	if err != nil {
+		return err
+	}
+
+	return api.SaveResponseValueAs(fmt.Sprintf("%s[%d]", arrayPath, index), name)
+}
+
+func (api *BaseFeature) phrasePredicate(path, phrase, operand string) (json_matcher.Predicate, error) {
+	op, ok := elementPhrases[phrase]
+	if !ok {
+		return nil, errors.Errorf("unknown comparison: %s", phrase)
+	}
+
+	operand, err := api.value(operand)
+	if err != nil {
+		return nil, err
+	}
+
+	return json_matcher.Where(path, op, operand), nil
+}
+
+func (api *BaseFeature) assertResponseElements(quantifier, arrayPath, path, phrase, operand string) error {
+	predicate, err := api.phrasePredicate(path, phrase, operand)
+	if err != nil {
+		return err
+	}
+
+	switch quantifier {
+	case "every":
+		return api.AssertResponseAllMatch(arrayPath, predicate)
+	case "no":
+		return api.AssertResponseNoneMatch(arrayPath, predicate)
+	}
+
+	return api.AssertResponseAnyMatch(arrayPath, predicate)
+}
+
+func (api *BaseFeature) saveResponseMatch(capture, arrayPath, path, phrase, operand, name string) error {
+	predicate, err := api.phrasePredicate(path, phrase, operand)
+	if err != nil {
+		return err
+	}
+
+	if capture == "index" {
+		return api.SaveResponseMatchIndexAs(arrayPath, predicate, name)
+	}
+
+	return api.SaveResponseMatchValueAs(arrayPath, predicate, name)
+}
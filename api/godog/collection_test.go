@@ -0,0 +1,76 @@
+package godog_test
+
+import (
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+const collectionFeature = `
+Feature: collections
+
+  Scenario: assert and capture elements
+    Given I create a "POST" request to "/nodes"
+    And I set the request header "X-Test" to "Pump 1"
+    And I set the request body parameter "tags" to the list "a, b, c"
+    When I execute the request
+    Then some element of ".data" should have ".method" equal to "POST"
+    And every element of ".data" should have ".path" starting with "/nodes"
+    And no element of ".data" should have ".header" equal to "Pump 2"
+    And some element of ".data[0].body.tags" should have "." equal to "c"
+    And the response value ".data" should contain an element like:
+      """
+      {"method": "POST", "header": "Pump 1", "body": {"tags": ["a", "b", "c"]}}
+      """
+    And I save the index of the element of ".data[0].body.tags" having "." equal to "b" as "index"
+    And I save the value of the element of ".data" having ".header" equal to "Pump 1" as "node"
+    When I create a "POST" request to "/nodes/{index}"
+    And I set the request path parameter "index" to ".index"
+    And I set the request body to:
+      """
+      {"node": {{ .node | json }}}
+      """
+    And I execute the request
+    Then the response value ".data[0].path" should equal "/nodes/1"
+    And the response value ".data[0].body.node.header" should equal "Pump 1"
+`
+
+func TestRegisterSteps_Collections(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	status := runFeature(t, collectionFeature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_FailingCollection(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := `
+Feature: collections
+
+  Scenario: assert elements
+    Given I create a "GET" request to "/nodes"
+    When I execute the request
+    Then every element of ".data" should have ".method" equal to "POST"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 1, status)
+}
@@ -0,0 +1,68 @@
+package godog
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// operatorPhrases maps the phrasing of the comparison step to operators.
+var operatorPhrases = map[string]json_matcher.Operator{
+	"not equal":       json_matcher.OpNotEqual,
+	"be greater than": json_matcher.OpGreater,
+	"be at least":     json_matcher.OpGreaterOrEqual,
+	"be less than":    json_matcher.OpLess,
+	"be at most":      json_matcher.OpLessOrEqual,
+	"contain":         json_matcher.OpContains,
+	"start with":      json_matcher.OpHasPrefix,
+	"end with":        json_matcher.OpHasSuffix,
+	"match":           json_matcher.OpMatches,
+	"be before":       json_matcher.OpBefore,
+	"be after":        json_matcher.OpAfter,
+}
+
+// AssertResponseValueCompares applies op to the response value at path and
+// operand, which is resolved with the `.` convention.
+func (api *BaseFeature) AssertResponseValueCompares(path string, op json_matcher.Operator, operand string) (err error) {
+	if operand, err = api.value(operand); err != nil {
+		return
+	}
+
+	return json_matcher.Compare(api.Response.Body, path, op, operand)
+}
+
+func (api *BaseFeature) assertResponseValuePhrase(path, phrase, operand string) error {
+	op, ok := operatorPhrases[phrase]
+	if !ok {
+		return errors.Errorf("unknown comparison: %s", phrase)
+	}
+
+	return api.AssertResponseValueCompares(path, op, operand)
+}
+
+func (api *BaseFeature) AssertResponseValueBetween(path string, min, max float64) error {
+	return json_matcher.Between(api.Response.Body, path, min, max)
+}
+
+// AssertResponseValueIsKind checks the type or format of the response value
+// at path, see json_matcher.Kind.
+func (api *BaseFeature) AssertResponseValueIsKind(path, kind string) error {
+	return json_matcher.IsKind(api.Response.Body, path, json_matcher.Kind(kind))
+}
+
+// AssertResponseValueWithinDurationOfNow checks that the timestamp at path
+// differs at most duration, e.g. `5m`, from now.
+func (api *BaseFeature) AssertResponseValueWithinDurationOfNow(path, duration string) error {
+	delta, err := time.ParseDuration(duration)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration: %s", duration)
+	}
+
+	return json_matcher.WithinDuration(api.Response.Body, path, time.Now(), delta)
+}
+
+func (api *BaseFeature) AssertResponseArrayLenBetween(path string, min, max int) error {
+	return json_matcher.ArrayLenBetween(api.Response.Body, path, min, max)
+}
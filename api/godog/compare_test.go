@@ -0,0 +1,75 @@
+package godog_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+const compareFeature = `
+Feature: comparisons
+
+  Scenario: compare response values
+    Given I create a "POST" request to "/nodes"
+    And I set the request body parameter "count" to the integer 3
+    And I set the request body parameter "id" to "0b1e3f6a-0c5e-4f5e-8d5b-3f3c3a1c9b1e"
+    And I set the request body parameter "createdAt" to "{{ now }}"
+    And I set the request body parameter "tags" to the list "a, b"
+    And I set the variable "max" to "3"
+    When I execute the request
+    Then the response value ".data[0].method" should not equal "GET"
+    And the response value ".data[0].body.count" should be greater than "2"
+    And the response value ".data[0].body.count" should be at most ".max"
+    And the response value ".data[0].body.count" should be between 1 and 3
+    And the response value ".data[0].body.count" should be a number
+    And the response value ".data[0].body.id" should be a uuid
+    And the response value ".data[0].path" should start with "/no"
+    And the response value ".data[0].path" should match "^/nodes$"
+    And the response value ".data[0].body.createdAt" should be before "now"
+    And the response value ".data[0].body.createdAt" should be within 1m of now
+    And the response value ".data[0].body.tags" should contain between 1 and 2 items
+`
+
+func TestRegisterSteps_Comparisons(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := strings.ReplaceAll(compareFeature, "{{ now }}", time.Now().UTC().Format(time.RFC3339))
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_FailingComparison(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := `
+Feature: comparisons
+
+  Scenario: compare response values
+    Given I create a "GET" request to "/nodes"
+    When I execute the request
+    Then the response value ".data[0].path" should end with "/edges"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 1, status)
+}
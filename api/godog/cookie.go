@@ -0,0 +1,149 @@
+package godog
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sameSiteModes maps the SameSite attribute values to their modes.
+var sameSiteModes = map[string]http.SameSite{
+	"strict": http.SameSiteStrictMode,
+	"lax":    http.SameSiteLaxMode,
+	"none":   http.SameSiteNoneMode,
+}
+
+// AssertResponseCookieEquals checks the value of the cookie name set by the
+// response, expected is resolved with the `.` convention.
+func (api *BaseFeature) AssertResponseCookieEquals(name, expected string) (err error) {
+	if expected, err = api.value(expected); err != nil {
+		return
+	}
+
+	cookie, err := api.responseCookie(name)
+	if err != nil {
+		return
+	}
+
+	if cookie.Value != expected {
+		return errors.Errorf("Cookie error: Expected %s to be '%s' got '%s'", name, expected, cookie.Value)
+	}
+
+	return
+}
+
+func (api *BaseFeature) AssertResponseCookieIsSecure(name string) error {
+	cookie, err := api.responseCookie(name)
+	if err != nil {
+		return err
+	}
+
+	if !cookie.Secure {
+		return errors.Errorf("Cookie error: Expected %s to be Secure: %s", name, cookie.Raw)
+	}
+
+	return nil
+}
+
+func (api *BaseFeature) AssertResponseCookieIsHTTPOnly(name string) error {
+	cookie, err := api.responseCookie(name)
+	if err != nil {
+		return err
+	}
+
+	if !cookie.HttpOnly {
+		return errors.Errorf("Cookie error: Expected %s to be HttpOnly: %s", name, cookie.Raw)
+	}
+
+	return nil
+}
+
+func (api *BaseFeature) assertResponseCookieAttribute(name, attribute string) error {
+	switch attribute {
+	case "secure":
+		return api.AssertResponseCookieIsSecure(name)
+	case "http only":
+		return api.AssertResponseCookieIsHTTPOnly(name)
+	}
+
+	return errors.Errorf("unknown cookie attribute: %s", attribute)
+}
+
+// AssertResponseCookieSameSite checks the SameSite attribute of the cookie
+// name, mode is Strict, Lax or None.
+func (api *BaseFeature) AssertResponseCookieSameSite(name, mode string) error {
+	expected, ok := sameSiteModes[strings.ToLower(mode)]
+	if !ok {
+		return errors.Errorf("Cookie error: Unknown SameSite mode %s", mode)
+	}
+
+	cookie, err := api.responseCookie(name)
+	if err != nil {
+		return err
+	}
+
+	if cookie.SameSite != expected {
+		return errors.Errorf("Cookie error: Expected %s to have SameSite=%s: %s", name, mode, cookie.Raw)
+	}
+
+	return nil
+}
+
+// AssertResponseCookieExpiresWithin checks that the cookie name isn't a
+// session cookie and expires within duration, e.g. `1h`, but not before the
+// response was received. Max-Age takes precedence over Expires.
+func (api *BaseFeature) AssertResponseCookieExpiresWithin(name, duration string) error {
+	within, err := time.ParseDuration(duration)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration: %s", duration)
+	}
+
+	cookie, err := api.responseCookie(name)
+	if err != nil {
+		return err
+	}
+
+	received := api.Request.ExecutionTime.Add(api.Response.Duration)
+
+	var expires time.Time
+
+	switch {
+	case cookie.MaxAge > 0:
+		expires = received.Add(time.Duration(cookie.MaxAge) * time.Second)
+	case cookie.MaxAge < 0:
+		return errors.Errorf("Cookie error: Expected %s to expire within %s, it is deleted: %s", name, duration, cookie.Raw)
+	case !cookie.Expires.IsZero():
+		expires = cookie.Expires
+	default:
+		return errors.Errorf("Cookie error: Expected %s to expire within %s, it is a session cookie: %s", name, duration, cookie.Raw)
+	}
+
+	if expires.Before(received) || expires.After(received.Add(within)) {
+		return errors.Errorf("Cookie error: Expected %s to expire within %s, it expires at %s", name, duration, expires.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// responseCookie returns the last cookie name set by the response.
+func (api *BaseFeature) responseCookie(name string) (*http.Cookie, error) {
+	if err := api.assertResponseReceived(); err != nil {
+		return nil, err
+	}
+
+	var found *http.Cookie
+
+	for _, cookie := range api.Response.Raw.Cookies() {
+		if cookie.Name == name {
+			found = cookie
+		}
+	}
+
+	if found == nil {
+		return nil, errors.Errorf("Cookie error: Expected %s to be set, Set-Cookie: %q", name, api.Response.Raw.Header.Values("Set-Cookie"))
+	}
+
+	return found, nil
+}
@@ -0,0 +1,117 @@
+package godog
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CreatePreflightRequest creates an OPTIONS request to path as sent by a
+// browser at origin before a method request, origin and method are resolved
+// with the `.` convention. Requested headers can be added by setting the
+// Access-Control-Request-Headers request header.
+func (api *BaseFeature) CreatePreflightRequest(path, origin, method string) (err error) {
+	if origin, err = api.value(origin); err != nil {
+		return
+	}
+
+	if method, err = api.value(method); err != nil {
+		return
+	}
+
+	if err = api.CreatePathRequest(http.MethodOptions, path); err != nil {
+		return
+	}
+
+	api.Request.Headers.Set("Origin", origin)
+	api.Request.Headers.Set("Access-Control-Request-Method", method)
+
+	return
+}
+
+// AssertCORSAllowed checks that the response allows requests from origin
+// with method and the headers requested by the Access-Control-Request-Headers
+// request header. GET, HEAD and POST don't have to be listed.
+func (api *BaseFeature) AssertCORSAllowed(origin, method string) (err error) {
+	if origin, err = api.value(origin); err != nil {
+		return
+	}
+
+	if method, err = api.value(method); err != nil {
+		return
+	}
+
+	if err = api.assertCORSOrigin(origin); err != nil {
+		return
+	}
+
+	header := api.Response.Raw.Header
+
+	allowedMethods := headerList(header, "Access-Control-Allow-Methods")
+	if !isSafelistedMethod(method) && !containsFold(allowedMethods, method) {
+		return errors.Errorf("CORS error: Expected %s to be allowed, Access-Control-Allow-Methods: %q", method, allowedMethods)
+	}
+
+	allowedHeaders := headerList(header, "Access-Control-Allow-Headers")
+	for _, requested := range headerList(api.Request.Headers, "Access-Control-Request-Headers") {
+		if !containsFold(allowedHeaders, requested) {
+			return errors.Errorf("CORS error: Expected header %s to be allowed, Access-Control-Allow-Headers: %q", requested, allowedHeaders)
+		}
+	}
+
+	return
+}
+
+// AssertCORSDenied checks that the response doesn't allow requests from
+// origin, which is resolved with the `.` convention.
+func (api *BaseFeature) AssertCORSDenied(origin string) (err error) {
+	if origin, err = api.value(origin); err != nil {
+		return
+	}
+
+	if err = api.assertResponseReceived(); err != nil {
+		return
+	}
+
+	if api.assertCORSOrigin(origin) == nil {
+		return errors.Errorf("CORS error: Expected %s to be denied, Access-Control-Allow-Origin: %s",
+			origin, api.Response.Raw.Header.Get("Access-Control-Allow-Origin"))
+	}
+
+	return
+}
+
+func (api *BaseFeature) assertCORSOrigin(origin string) error {
+	if err := api.assertResponseReceived(); err != nil {
+		return err
+	}
+
+	allowed := api.Response.Raw.Header.Get("Access-Control-Allow-Origin")
+	if allowed != "*" && allowed != origin {
+		return errors.Errorf("CORS error: Expected %s to be allowed, Access-Control-Allow-Origin: '%s'", origin, allowed)
+	}
+
+	return nil
+}
+
+func isSafelistedMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+		return true
+	}
+
+	return false
+}
+
+// containsFold reports whether list contains value or the `*` wildcard,
+// ignoring case.
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if item == "*" || strings.EqualFold(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
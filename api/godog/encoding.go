@@ -0,0 +1,227 @@
+package godog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/go-http-utils/headers"
+	"github.com/pkg/errors"
+)
+
+// BodyMode decides how Request.Body, Request.Form, Request.Files and
+// Request.RawBody are encoded when the request is executed.
+type BodyMode int
+
+const (
+	BodyModeJSON BodyMode = iota
+	BodyModeForm
+	BodyModeMultipart
+	BodyModeRaw
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeForm = "application/x-www-form-urlencoded"
+)
+
+// quoteEscaper escapes quoted Content-Disposition parameters, line breaks
+// are percent-encoded the way browsers do.
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "%0D", "\n", "%0A")
+
+var bodyModes = map[string]BodyMode{
+	"json":      BodyModeJSON,
+	"form":      BodyModeForm,
+	"multipart": BodyModeMultipart,
+	"raw":       BodyModeRaw,
+}
+
+// FormFile is a file sent as the multipart field Field, Path is relative to
+// the fixtures directory.
+type FormFile struct {
+	Field string
+	Path  string
+}
+
+func (api *BaseFeature) SetFixturesDir(dir string) {
+	api.fixturesDir = dir
+}
+
+// SetRequestBodyMode sets the body mode by name, one of json, form,
+// multipart or raw.
+func (api *BaseFeature) SetRequestBodyMode(mode string) error {
+	bodyMode, exists := bodyModes[strings.ToLower(mode)]
+	if !exists {
+		return errors.Errorf("unknown body mode: '%s'", mode)
+	}
+
+	api.Request.BodyMode = bodyMode
+
+	return nil
+}
+
+// SetRequestFormFieldTo adds value to the form field key and switches a JSON
+// request to a URL-encoded form.
+func (api *BaseFeature) SetRequestFormFieldTo(key, value string) (err error) {
+	if strings.HasPrefix(value, ".") {
+		if value, err = api.value(value); err != nil {
+			return err
+		}
+	}
+
+	if api.Request.Form == nil {
+		api.Request.Form = make(url.Values)
+	}
+
+	api.Request.Form.Add(key, value)
+
+	if api.Request.BodyMode == BodyModeJSON {
+		api.Request.BodyMode = BodyModeForm
+	}
+
+	return
+}
+
+// SetRequestFileFieldTo attaches the fixture filename as the multipart field
+// and switches the request to multipart.
+func (api *BaseFeature) SetRequestFileFieldTo(field, filename string) error {
+	path := filepath.Join(api.fixturesDir, filename)
+	if _, err := os.Stat(path); err != nil {
+		return errors.Wrapf(err, "fixture '%s' not found", filename)
+	}
+
+	api.Request.Files = append(api.Request.Files, FormFile{Field: field, Path: path})
+	api.Request.BodyMode = BodyModeMultipart
+
+	return nil
+}
+
+func (api *BaseFeature) SetRequestRawBodyTo(body string) error {
+	api.Request.RawBody = []byte(body)
+	api.Request.BodyMode = BodyModeRaw
+
+	return nil
+}
+
+func (api *BaseFeature) SetRequestRawBodyFromDocString(body *godog.DocString) error {
+	return api.SetRequestRawBodyTo(body.Content)
+}
+
+// requestPayload encodes the request body according to its body mode and
+// keeps the matching Content-Type for the executed request, see
+// Request.header. GET requests are sent without a body.
+func (api *BaseFeature) requestPayload() ([]byte, error) {
+	api.Request.contentType = ""
+
+	if api.Request.Method == http.MethodGet {
+		return nil, nil
+	}
+
+	payload, contentType, err := api.Request.encodeBody()
+	if err != nil {
+		return nil, err
+	}
+
+	api.Request.contentType = contentType
+
+	return payload, nil
+}
+
+// header returns the headers sent with the request, the Content-Type of the
+// body mode is added unless one is set. The multipart boundary is generated
+// for every encoding so it always has to be replaced.
+func (r *Request) header() http.Header {
+	header := r.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	if r.contentType != "" && (header.Get(headers.ContentType) == "" || r.BodyMode == BodyModeMultipart) {
+		header.Set(headers.ContentType, r.contentType)
+	}
+
+	return header
+}
+
+func (r *Request) encodeBody() (payload []byte, contentType string, err error) {
+	switch r.BodyMode {
+	case BodyModeJSON:
+		if payload, err = json.Marshal(r.Body); err != nil {
+			return nil, "", errors.Wrap(err, "json.Marshal failed")
+		}
+
+		return payload, contentTypeJSON, nil
+	case BodyModeForm:
+		return []byte(r.Form.Encode()), contentTypeForm, nil
+	case BodyModeMultipart:
+		return r.encodeMultipart()
+	case BodyModeRaw:
+		return r.RawBody, "", nil
+	}
+
+	return nil, "", errors.Errorf("unknown body mode: %d", r.BodyMode)
+}
+
+func (r *Request) encodeMultipart() ([]byte, string, error) {
+	var buffer bytes.Buffer
+
+	writer := multipart.NewWriter(&buffer)
+
+	// Sorted keys make the body the same for every execution
+	keys := make([]string, 0, len(r.Form))
+	for key := range r.Form {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range r.Form[key] {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, "", errors.Wrap(err, "failed to write multipart field")
+			}
+		}
+	}
+
+	for _, file := range r.Files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed to read fixture '%s'", file.Path)
+		}
+
+		fileContentType := mime.TypeByExtension(filepath.Ext(file.Path))
+		if fileContentType == "" {
+			fileContentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set(headers.ContentDisposition,
+			fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(file.Field), quoteEscaper.Replace(filepath.Base(file.Path))))
+		header.Set(headers.ContentType, fileContentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to create multipart file")
+		}
+
+		if _, err = part.Write(content); err != nil {
+			return nil, "", errors.Wrap(err, "failed to write multipart file")
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "failed to close multipart writer")
+	}
+
+	return buffer.Bytes(), writer.FormDataContentType(), nil
+}
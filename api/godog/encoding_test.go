@@ -0,0 +1,140 @@
+package godog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteTheRequest_Form(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		if !assert.NoError(t, r.ParseForm()) {
+			return
+		}
+
+		assert.Equal(t, []string{"a", "b c"}, r.PostForm["name"])
+		assert.Equal(t, []string{"user-1"}, r.PostForm["userId"])
+	}))
+	defer s.Close()
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL)
+	api.Variables.Set("userId", "user-1")
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/form"))
+	require.NoError(t, api.SetRequestFormFieldTo("name", "a"))
+	require.NoError(t, api.SetRequestFormFieldTo("name", "b c"))
+	require.NoError(t, api.SetRequestFormFieldTo("userId", ".userId"))
+	require.NoError(t, api.ExecuteTheRequest())
+	require.NoError(t, api.AssertResponseCode(http.StatusOK))
+}
+
+func TestExecuteTheRequest_Multipart(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Content-Type"), "multipart/form-data; boundary=")
+		if !assert.NoError(t, r.ParseMultipartForm(1024)) {
+			return
+		}
+
+		assert.Equal(t, []string{"report"}, r.MultipartForm.Value["name"])
+
+		file, header, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "upload.txt", header.Filename)
+		assert.Equal(t, "text/plain; charset=utf-8", header.Header.Get("Content-Type"))
+		assert.Equal(t, "hello fixture\n", string(content))
+	}))
+	defer s.Close()
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL)
+	api.SetFixturesDir("testdata/fixtures")
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/upload"))
+	require.NoError(t, api.SetRequestFormFieldTo("name", "report"))
+	require.NoError(t, api.SetRequestFileFieldTo("file", "upload.txt"))
+	require.Error(t, api.SetRequestFileFieldTo("file", "missing.txt"))
+	require.NoError(t, api.ExecuteTheRequest())
+	require.NoError(t, api.AssertResponseCode(http.StatusOK))
+	assert.Empty(t, api.Request.Headers.Get("Content-Type"), "the request headers are left as set")
+}
+
+func TestExecuteTheRequest_JSONContentType(t *testing.T) {
+	var contentTypes []string
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+	}))
+	defer s.Close()
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/nodes"))
+	require.NoError(t, api.SetRequestBodyParameterTo("name", "a"))
+	require.NoError(t, api.ExecuteTheRequest())
+	assert.Empty(t, api.Request.Headers.Values("Content-Type"))
+
+	require.NoError(t, api.SetRequestRawBodyTo("name=a"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	require.NoError(t, api.SetRequestHeaderParameterTo("Content-Type", "application/merge-patch+json"))
+	require.NoError(t, api.SetRequestBodyMode("json"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/nodes"))
+	require.NoError(t, api.SetRequestFormFieldTo("name", "a"))
+	require.NoError(t, api.ExecuteTheRequest())
+	require.NoError(t, api.ExecuteInvalidRequest())
+
+	assert.Equal(t, []string{
+		"application/json", "", "application/merge-patch+json",
+		"application/x-www-form-urlencoded", "application/json",
+	}, contentTypes)
+}
+
+func TestRequest_EncodeMultipart(t *testing.T) {
+	request := Request{
+		Form:  map[string][]string{"b": {"2"}, "a": {"1"}, "c": {"3"}},
+		Files: []FormFile{{Field: `fi"le`, Path: "testdata/fixtures/upload.txt"}},
+	}
+
+	first, _, err := request.encodeMultipart()
+	require.NoError(t, err)
+
+	second, _, err := request.encodeMultipart()
+	require.NoError(t, err)
+
+	boundary := regexp.MustCompile(`--[0-9a-f]+`)
+	assert.Equal(t, string(boundary.ReplaceAll(first, nil)), string(boundary.ReplaceAll(second, nil)))
+	assert.Regexp(t, `(?s)name="a".*name="b".*name="c".*name="fi\\"le"; filename="upload.txt"`, string(first))
+}
+
+func TestQuoteEscaper(t *testing.T) {
+	assert.Equal(t, `a\\b\"c%0D%0Ad.txt`, quoteEscaper.Replace("a\\b\"c\r\nd.txt"))
+}
+
+func TestBaseFeature_SetRequestBodyMode(t *testing.T) {
+	api := BaseFeature{}
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/"))
+	require.NoError(t, api.SetRequestBodyMode("multipart"))
+	assert.Equal(t, BodyModeMultipart, api.Request.BodyMode)
+
+	require.NoError(t, api.SetRequestFormFieldTo("name", "a"))
+	assert.Equal(t, BodyModeMultipart, api.Request.BodyMode)
+
+	assert.Error(t, api.SetRequestBodyMode("xml"))
+}
@@ -0,0 +1,47 @@
+package godog
+
+import (
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// SetEqualOptions sets the options used by every response body comparison,
+// e.g. json_matcher.IgnoreArrayOrder() or json_matcher.NumericTolerance(0.01).
+func (api *BaseFeature) SetEqualOptions(opts ...json_matcher.EqualOption) {
+	api.equalOptions = opts
+}
+
+// AssertResponseBodyEquals compares the whole response body with expected,
+// see json_matcher.Equal for the placeholders expected can contain. Expected
+// is rendered as a template first, like request bodies.
+func (api *BaseFeature) AssertResponseBodyEquals(expected string, opts ...json_matcher.EqualOption) error {
+	rendered, err := api.renderTemplate(expected)
+	if err != nil {
+		return err
+	}
+
+	opts = append(append([]json_matcher.EqualOption{}, api.equalOptions...), opts...)
+
+	return json_matcher.Equal(api.Response.Body, rendered, opts...)
+}
+
+func (api *BaseFeature) AssertResponseBodyEqualsDocString(expected *godog.DocString) error {
+	return api.AssertResponseBodyEquals(expected.Content)
+}
+
+// AssertResponseBodyEqualsIgnoringDocString compares the whole response body
+// with expected, skipping the comma separated paths in ignoredPaths.
+func (api *BaseFeature) AssertResponseBodyEqualsIgnoringDocString(ignoredPaths string, expected *godog.DocString) error {
+	var paths []string
+
+	for _, path := range strings.Split(ignoredPaths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return api.AssertResponseBodyEquals(expected.Content, json_matcher.IgnorePaths(paths...))
+}
@@ -0,0 +1,27 @@
+package godog
+
+import (
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+func TestBaseFeature_AssertResponseBodyEquals(t *testing.T) {
+	api := BaseFeature{}
+	api.Variables.Set("name", "Pump 1")
+	api.Response.Body = []byte(`{"data": {"id": "0b1e3f6a-0c5e-4f5e-8d5b-3f3c3a1c9b1e", "name": "Pump 1", "tags": ["b", "a"]}}`)
+
+	assert.NoError(t, api.AssertResponseBodyEquals(`{"data": {"id": "<uuid>", "name": "{{ .name }}", "tags": "<any>"}}`))
+	assert.Error(t, api.AssertResponseBodyEquals(`{"data": {"id": "<uuid>", "name": "{{ .name }}", "tags": ["a", "b"]}}`))
+
+	api.SetEqualOptions(json_matcher.IgnoreArrayOrder())
+	assert.NoError(t, api.AssertResponseBodyEquals(`{"data": {"id": "<uuid>", "name": "{{ .name }}", "tags": ["a", "b"]}}`))
+
+	err := api.AssertResponseBodyEqualsIgnoringDocString(".data.id, .data.tags", &godog.DocString{Content: `{"data": {"name": "Pump 2"}}`})
+	require.ErrorContains(t, err, `.data.name`)
+	require.ErrorContains(t, err, `1 difference(s)`)
+}
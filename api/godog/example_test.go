@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+	"github.com/SKF/go-tests-utility/api/godog/openapi"
+	"github.com/SKF/go-tests-utility/api/godog/retry"
 )
 
 func TestGetRequest(t *testing.T) {
@@ -73,3 +77,107 @@ func TestCreateInvalidRequest(t *testing.T) {
 	err = api.AssertResponseCode(http.StatusInternalServerError)
 	require.NoError(t, err)
 }
+
+func TestGetRequestWithQuery(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/todos", r.URL.Path)
+		assert.Equal(t, []string{"1"}, r.URL.Query()["userId"])
+		assert.Equal(t, []string{"a&b", "c d"}, r.URL.Query()["tag"])
+		assert.Equal(t, []string{"10"}, r.URL.Query()["limit"])
+
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer s.Close()
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL)
+	api.Variables.Set("userId", float64(1))
+
+	err := api.CreatePathRequest(http.MethodGet, "/todos?limit=10")
+	require.NoError(t, err)
+
+	require.NoError(t, api.SetRequestQueryParameterTo("userId", ".userId"))
+	require.NoError(t, api.SetRequestQueryParameterTo("tag", "a&b"))
+	require.NoError(t, api.SetRequestQueryParameterTo("tag", "c d"))
+
+	err = api.ExecuteTheRequest()
+	require.NoError(t, err)
+
+	err = api.AssertResponseCode(http.StatusOK)
+	assert.NoError(t, err)
+}
+
+func TestSetHTTPClient(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer s.Close()
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL)
+	api.SetHTTPClient(s.Client())
+
+	err := api.CreatePathRequest(http.MethodGet, "/")
+	require.NoError(t, err)
+
+	err = api.ExecuteTheRequest()
+	require.NoError(t, err)
+
+	err = api.AssertResponseCode(http.StatusOK)
+	assert.NoError(t, err)
+}
+
+func TestSetContract(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"id":"1"}`)
+	}))
+	defer s.Close()
+
+	contract, err := openapi.Load("openapi/testdata/nodes.yaml")
+	require.NoError(t, err)
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL + "/v1")
+	api.SetContract(contract)
+
+	err = api.CreatePathRequest(http.MethodGet, "/nodes/1")
+	require.NoError(t, err)
+
+	err = api.ExecuteTheRequest()
+	require.ErrorContains(t, err, "response 200 of GET /nodes/{id} doesn't match the contract")
+}
+
+func TestExecuteTheRequestUntilResponse(t *testing.T) {
+	var requests int32
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprintln(w, `{"data": {"id": "1"}}`)
+	}))
+	defer s.Close()
+
+	api := BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	err := api.CreatePathRequest(http.MethodGet, "/nodes/1")
+	require.NoError(t, err)
+
+	err = api.ExecuteTheRequestUntilResponse(retry.UntilResponse{
+		Condition: retry.And(
+			retry.StatusIs(http.StatusOK),
+			retry.HeaderExists("ETag"),
+			retry.JSONPathEquals(".data.id", "1"),
+		),
+		Timeout: time.Second,
+		Options: []retry.Option{retry.WithBackoff(retry.Constant(time.Millisecond))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Positive(t, api.Response.Duration)
+}
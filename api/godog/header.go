@@ -0,0 +1,128 @@
+package godog
+
+import (
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// AssertResponseHeaderEquals checks that one of the values of the response
+// header name is expected, which is resolved with the `.` convention.
+func (api *BaseFeature) AssertResponseHeaderEquals(name, expected string) (err error) {
+	if expected, err = api.value(expected); err != nil {
+		return
+	}
+
+	values, err := api.responseHeaderValues(name)
+	if err != nil {
+		return
+	}
+
+	for _, value := range values {
+		if value == expected {
+			return nil
+		}
+	}
+
+	return errors.Errorf("Header error: Expected %s to be '%s' got %q", name, expected, values)
+}
+
+// AssertResponseHeaderMatches checks that one of the values of the response
+// header name matches the regular expression pattern.
+func (api *BaseFeature) AssertResponseHeaderMatches(name, pattern string) error {
+	expr, err := regexp.Compile(pattern)
+	if err != nil {
+		return errors.Wrapf(err, "Header error: Invalid pattern %s", pattern)
+	}
+
+	values, err := api.responseHeaderValues(name)
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		if expr.MatchString(value) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("Header error: Expected %s to match '%s' got %q", name, pattern, values)
+}
+
+func (api *BaseFeature) AssertResponseHeaderMissing(name string) error {
+	if err := api.assertResponseReceived(); err != nil {
+		return err
+	}
+
+	if values := api.Response.Raw.Header.Values(name); len(values) > 0 {
+		return errors.Errorf("Header error: Expected %s to be missing got %q", name, values)
+	}
+
+	return nil
+}
+
+// AssertResponseContentType checks the media type of the response ignoring
+// parameters such as charset, e.g. `application/json` matches
+// `application/json; charset=utf-8`.
+func (api *BaseFeature) AssertResponseContentType(expected string) error {
+	values, err := api.responseHeaderValues("Content-Type")
+	if err != nil {
+		return err
+	}
+
+	expectedType, _, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return errors.Wrapf(err, "Header error: Invalid content type %s", expected)
+	}
+
+	actualType, _, err := mime.ParseMediaType(values[0])
+	if err != nil {
+		return errors.Wrapf(err, "Header error: Invalid Content-Type %s", values[0])
+	}
+
+	if actualType != expectedType {
+		return errors.Errorf("Header error: Expected Content-Type %s got %s", expectedType, values[0])
+	}
+
+	return nil
+}
+
+func (api *BaseFeature) responseHeaderValues(name string) ([]string, error) {
+	if err := api.assertResponseReceived(); err != nil {
+		return nil, err
+	}
+
+	values := api.Response.Raw.Header.Values(name)
+	if len(values) == 0 {
+		return nil, errors.Errorf("Header error: Expected %s to be present, headers: %v", name, api.Response.Raw.Header)
+	}
+
+	return values, nil
+}
+
+func (api *BaseFeature) assertResponseReceived() error {
+	if api.Response.Raw == nil {
+		return errors.New("Header error: No response has been received")
+	}
+
+	return nil
+}
+
+// headerList splits comma separated header values such as
+// `Access-Control-Allow-Methods: GET, POST`.
+func headerList(header http.Header, name string) []string {
+	var list []string
+
+	for _, value := range header.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
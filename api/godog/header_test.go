@@ -0,0 +1,149 @@
+package godog_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+// newSessionServer sets headers and cookies like a login endpoint and
+// answers CORS preflight requests from https://app.example.com.
+func newSessionServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			if r.Header.Get("Origin") == "https://app.example.com" {
+				w.Header().Set("Access-Control-Allow-Origin", "https://app.example.com")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, DELETE")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Request-Id", "3f9c1e2a")
+		http.SetCookie(w, &http.Cookie{
+			Name:     "session",
+			Value:    "abc123",
+			MaxAge:   3600,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+		http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark"})
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"data": {}}`)
+	}))
+}
+
+func TestRegisterSteps_HeadersAndCookies(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	feature := `
+Feature: headers and cookies
+
+  Scenario: log in
+    Given I create a "POST" request to "/login"
+    And I set the variable "requestID" to "3f9c1e2a"
+    When I execute the request
+    Then the response header "X-Request-Id" should equal ".requestID"
+    And the response header "Content-Type" should match "^application/json"
+    And the response header "Location" should be missing
+    And the response content type should be "application/json"
+    And the response cookie "session" should equal "abc123"
+    And the response cookie "session" should be secure
+    And the response cookie "session" should be http only
+    And the response cookie "session" should have SameSite Strict
+    And the response cookie "session" should expire within 2h
+
+  Scenario: allow the app
+    Given I create a CORS preflight request to "/nodes" from "https://app.example.com" for "PUT"
+    And I set the request header "Access-Control-Request-Headers" to "authorization"
+    When I execute the request
+    Then the response should allow CORS from "https://app.example.com" for "PUT"
+
+  Scenario: deny other origins
+    Given I create a CORS preflight request to "/nodes" from "https://evil.example.com" for "DELETE"
+    When I execute the request
+    Then the response should not allow CORS from "https://evil.example.com"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestHeaderAssertions(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/login"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	require.EqualError(t, api.AssertResponseHeaderEquals("X-Request-Id", "other"),
+		`Header error: Expected X-Request-Id to be 'other' got ["3f9c1e2a"]`)
+	require.Error(t, api.AssertResponseHeaderMatches("X-Request-Id", "^[0-9]+$"))
+	require.EqualError(t, api.AssertResponseHeaderMissing("X-Request-Id"),
+		`Header error: Expected X-Request-Id to be missing got ["3f9c1e2a"]`)
+	require.EqualError(t, api.AssertResponseContentType("text/plain"),
+		"Header error: Expected Content-Type text/plain got application/json; charset=utf-8")
+	require.NoError(t, api.AssertResponseContentType("Application/JSON"))
+}
+
+func TestCookieAssertions(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/login"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	require.EqualError(t, api.AssertResponseCookieEquals("theme", "light"), "Cookie error: Expected theme to be 'light' got 'dark'")
+	require.EqualError(t, api.AssertResponseCookieIsSecure("theme"), "Cookie error: Expected theme to be Secure: theme=dark")
+	require.Error(t, api.AssertResponseCookieIsHTTPOnly("theme"))
+	require.Error(t, api.AssertResponseCookieSameSite("session", "Lax"))
+	require.EqualError(t, api.AssertResponseCookieExpiresWithin("theme", "1h"),
+		"Cookie error: Expected theme to expire within 1h, it is a session cookie: theme=dark")
+	require.Error(t, api.AssertResponseCookieExpiresWithin("session", "30m"))
+	require.ErrorContains(t, api.AssertResponseCookieEquals("missing", ""), "Cookie error: Expected missing to be set")
+}
+
+func TestCORSAssertions(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePreflightRequest("/nodes", "https://app.example.com", http.MethodPatch))
+	require.NoError(t, api.SetRequestHeaderParameterTo("Access-Control-Request-Headers", "Authorization, X-Custom"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	require.EqualError(t, api.AssertCORSAllowed("https://app.example.com", http.MethodPatch),
+		`CORS error: Expected PATCH to be allowed, Access-Control-Allow-Methods: ["GET" "PUT" "DELETE"]`)
+	require.EqualError(t, api.AssertCORSAllowed("https://app.example.com", http.MethodPost),
+		`CORS error: Expected header X-Custom to be allowed, Access-Control-Allow-Headers: ["Authorization" "Content-Type"]`)
+	require.EqualError(t, api.AssertCORSDenied("https://app.example.com"),
+		"CORS error: Expected https://app.example.com to be denied, Access-Control-Allow-Origin: https://app.example.com")
+	require.Error(t, api.AssertCORSAllowed("https://evil.example.com", http.MethodGet))
+}
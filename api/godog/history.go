@@ -0,0 +1,243 @@
+package godog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// DefaultHistorySize is how many exchanges History keeps unless
+// SetHistorySize is used.
+const DefaultHistorySize = 20
+
+// Exchange is an executed request and the response to it.
+type Exchange struct {
+	Request  Request
+	Response response
+}
+
+// History returns the last exchanges executed in the current scenario,
+// oldest first. Every execution is recorded, including the ones of retries
+// and pagination, see SetHistorySize for how many are kept.
+func (api *BaseFeature) History() []Exchange {
+	return api.history
+}
+
+// SetHistorySize sets how many of the last exchanges History keeps,
+// DefaultHistorySize by default, zero turns the history off. Saved aliases
+// are kept regardless.
+func (api *BaseFeature) SetHistorySize(size int) {
+	api.historySize = &size
+}
+
+// ResetHistory forgets the executed exchanges and their aliases,
+// RegisterSteps calls it before every scenario.
+func (api *BaseFeature) ResetHistory() {
+	api.history = nil
+	api.aliases = nil
+}
+
+// SaveExchangeAs names the current request and response alias. Steps
+// starting with `the response` can then assert on them with
+// `the response of <alias> ...`, and `.<alias>.<path>` looks up values in
+// the response body.
+func (api *BaseFeature) SaveExchangeAs(alias string) error {
+	if api.Response.Raw == nil {
+		return errors.Errorf("no request has been executed to save as %s", alias)
+	}
+
+	if api.aliases == nil {
+		api.aliases = make(map[string]Exchange)
+	}
+
+	api.aliases[alias] = Exchange{Request: api.Request.clone(), Response: api.Response}
+
+	return nil
+}
+
+// ExecuteTheRequestAs executes the request and saves it as alias, see
+// SaveExchangeAs.
+func (api *BaseFeature) ExecuteTheRequestAs(alias string) error {
+	if err := api.ExecuteTheRequest(); err != nil {
+		return err
+	}
+
+	return api.SaveExchangeAs(alias)
+}
+
+// LookupExchange returns the exchange saved as alias.
+func (api *BaseFeature) LookupExchange(alias string) (Exchange, error) {
+	exchange, exists := api.aliases[alias]
+	if !exists {
+		return Exchange{}, errors.Errorf("no response has been saved as %s", alias)
+	}
+
+	return exchange, nil
+}
+
+// WithExchange calls fn with the request and response saved as alias as the
+// current ones, e.g. to use any of the assertions on an earlier response.
+// RegisterReporter attaches the exchange of alias when the step fails.
+func (api *BaseFeature) WithExchange(alias string, fn func() error) error {
+	exchange, err := api.LookupExchange(alias)
+	if err != nil {
+		return err
+	}
+
+	request, resp := api.Request, api.Response
+	defer func() {
+		api.Request, api.Response = request, resp
+	}()
+
+	api.Request, api.Response = exchange.Request, exchange.Response
+	api.usedExchange = &exchange
+
+	if err = fn(); err != nil {
+		return errors.Wrapf(err, "response of %s", alias)
+	}
+
+	return nil
+}
+
+func (api *BaseFeature) recordExchange() {
+	size := DefaultHistorySize
+	if api.historySize != nil {
+		size = *api.historySize
+	}
+
+	if size <= 0 {
+		api.history = nil
+		return
+	}
+
+	history := append(api.history, Exchange{Request: api.Request.clone(), Response: api.Response})
+	if len(history) > size {
+		// copy to let go of the dropped exchanges
+		history = append([]Exchange(nil), history[len(history)-size:]...)
+	}
+
+	api.history = history
+}
+
+// historyValue resolves `.<alias>` to the body of the response saved as
+// alias and `.<alias>.<path>` to the value at path in it.
+func (api *BaseFeature) historyValue(key string) (value string, found bool, err error) {
+	if !strings.HasPrefix(key, ".") || len(api.aliases) == 0 {
+		return "", false, nil
+	}
+
+	alias := strings.TrimPrefix(key, ".")
+	path := ""
+
+	if idx := strings.IndexAny(alias, ".["); idx >= 0 {
+		alias, path = alias[:idx], alias[idx:]
+	}
+
+	exchange, exists := api.aliases[alias]
+	if !exists {
+		return "", false, nil
+	}
+
+	if path == "" {
+		return string(exchange.Response.Body), true, nil
+	}
+
+	if strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+
+	value, err = json_matcher.Read(exchange.Response.Body, path)
+
+	return value, true, err
+}
+
+// historyTemplateValue returns the decoded response body saved as alias for
+// use in templates, e.g. `{{ .createNode.data.id }}`.
+func (api *BaseFeature) historyTemplateValue(alias string) (interface{}, bool) {
+	exchange, exists := api.aliases[alias]
+	if !exists {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(exchange.Response.Body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(exchange.Response.Body), true
+	}
+
+	return value, true
+}
+
+// historyModifier runs a step starting with `the response` on a saved
+// response, e.g. `the response of createNode code should be 201`.
+type historyModifier struct {
+	api   *BaseFeature
+	steps *stepMatcher
+}
+
+// responseOf runs step, which gets the docstring or table of the step.
+func (m *historyModifier) responseOf(alias, step string) error {
+	call, err := m.steps.find("the response " + step)
+	if err != nil {
+		return err
+	}
+
+	return m.api.WithExchange(alias, call)
+}
+
+// clone copies the request so that later changes to the current request
+// don't change the history.
+func (r Request) clone() Request {
+	clone := r
+	clone.Headers = r.Headers.Clone()
+	clone.Query = cloneValues(r.Query)
+	clone.Form = cloneValues(r.Form)
+	clone.Files = append([]FormFile(nil), r.Files...)
+
+	if r.Body != nil {
+		clone.Body, _ = cloneJSON(r.Body).(map[string]interface{})
+	}
+
+	return clone
+}
+
+func cloneValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+
+	clone := make(url.Values, len(values))
+	for key, value := range values {
+		clone[key] = append([]string(nil), value...)
+	}
+
+	return clone
+}
+
+func cloneJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(value))
+		for key, element := range value {
+			clone[key] = cloneJSON(element)
+		}
+
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(value))
+		for idx, element := range value {
+			clone[idx] = cloneJSON(element)
+		}
+
+		return clone
+	}
+
+	return value
+}
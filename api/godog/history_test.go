@@ -0,0 +1,176 @@
+package godog_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+// newNodesServer creates nodes with increasing ids on POST and returns the
+// node of the path on GET.
+func newNodesServer() *httptest.Server {
+	var nextID int32
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"data": {"id": "node-%d", "tags": ["pump"]}}`, atomic.AddInt32(&nextID, 1))
+
+			return
+		}
+
+		fmt.Fprintf(w, `{"data": {"id": "%s", "path": "%s"}}`, r.URL.Path[len("/nodes/"):], r.URL.Path)
+	}))
+}
+
+func TestRegisterSteps_History(t *testing.T) {
+	s := newNodesServer()
+	defer s.Close()
+
+	feature := `
+Feature: history
+
+  Scenario: refer to earlier responses
+    Given I create a "POST" request to "/nodes"
+    And I execute the request as "createPump"
+    And I create a "POST" request to "/nodes"
+    And I execute the request
+    And I save the response as "createMotor"
+    When I create a "GET" request to "/nodes/{id}"
+    And I set the request path parameter "id" to ".createPump.data.id"
+    And I execute the request
+    Then the response value ".data.path" should equal "/nodes/node-1"
+    And the response of createPump code should be 201
+    And the response of createPump value ".data.id" should equal "node-1"
+    And the response of createMotor value ".data.id" should equal "node-2"
+    And the response of createMotor value ".data.tags[0]" should equal ".createPump.data.tags[0]"
+    And the response of createMotor body should equal:
+      """
+      {"data": {"id": "node-2", "tags": ["pump"]}}
+      """
+    And the response code should be 200
+    And I set the request body to:
+      """
+      {"parents": [{{ .createPump.data.id | json }}, {{ .createMotor.data.id | json }}]}
+      """
+    And the response value ".data.id" should equal "node-1"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+
+		sc.StepContext().After(func(ctx context.Context, step *godog.Step, _ godog.StepResultStatus, err error) (context.Context, error) {
+			if err == nil && step.Text == "I set the request body to:" {
+				require.Equal(t, map[string]interface{}{"parents": []interface{}{"node-1", "node-2"}}, api.Request.Body)
+			}
+
+			return ctx, nil
+		})
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_HistoryUnknownAlias(t *testing.T) {
+	s := newNodesServer()
+	defer s.Close()
+
+	feature := `
+Feature: history
+
+  Scenario: refer to an unknown response
+    Given I create a "POST" request to "/nodes"
+    When I execute the request
+    Then the response of createPump code should be 201
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 1, status)
+}
+
+func TestHistory(t *testing.T) {
+	s := newNodesServer()
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.EqualError(t, api.SaveExchangeAs("nothing"), "no request has been executed to save as nothing")
+
+	require.NoError(t, api.CreatePathRequest(http.MethodPost, "/nodes"))
+	require.NoError(t, api.SetRequestHeaderParameterTo("X-Test", "first"))
+	require.NoError(t, api.SetRequestBodyParameterTo("parent.name", "Site 1"))
+	require.NoError(t, api.ExecuteTheRequestAs("first"))
+
+	// Changing the current request doesn't change the history
+	require.NoError(t, api.SetRequestHeaderParameterTo("X-Test", "second"))
+	require.NoError(t, api.SetRequestBodyParameterTo("parent.name", "Site 2"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	history := api.History()
+	require.Len(t, history, 2)
+	require.Equal(t, []string{"first"}, history[0].Request.Headers.Values("X-Test"))
+	require.Equal(t, map[string]interface{}{"name": "Site 1"}, history[0].Request.Body["parent"])
+	require.JSONEq(t, `{"data": {"id": "node-2", "tags": ["pump"]}}`, string(history[1].Response.Body))
+
+	err := api.WithExchange("first", func() error {
+		return api.AssertResponseBodyValueEquals(".data.id", "node-2")
+	})
+	require.EqualError(t, err, "response of first: Match error: Values mismatch, expected: 'node-2' actual: 'node-1'")
+	require.NoError(t, api.AssertResponseBodyValueEquals(".data.id", "node-2"), "the current response is restored")
+
+	exchange, err := api.LookupExchange("first")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, exchange.Response.Raw.StatusCode)
+
+	api.ResetHistory()
+	require.Empty(t, api.History())
+
+	_, err = api.LookupExchange("first")
+	require.EqualError(t, err, "no response has been saved as first")
+}
+
+func TestHistory_Size(t *testing.T) {
+	s := newNodesServer()
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+	api.SetHistorySize(2)
+
+	for _, name := range []string{"first", "second", "third"} {
+		require.NoError(t, api.CreatePathRequest(http.MethodGet, "/nodes/"+name))
+		require.NoError(t, api.ExecuteTheRequest())
+	}
+
+	history := api.History()
+	require.Len(t, history, 2)
+	require.Equal(t, s.URL+"/nodes/second", history[0].Request.Url)
+	require.Equal(t, s.URL+"/nodes/third", history[1].Request.Url)
+
+	api.SetHistorySize(0)
+	require.NoError(t, api.ExecuteTheRequestAs("last"))
+	require.Empty(t, api.History())
+
+	_, err := api.LookupExchange("last")
+	require.NoError(t, err)
+}
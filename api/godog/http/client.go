@@ -0,0 +1,163 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	dd_http "gopkg.in/DataDog/dd-trace-go.v1/contrib/net/http"
+)
+
+// DefaultTimeout is the request timeout of clients created by NewClient
+// unless WithTimeout is used.
+const DefaultTimeout = 60 * time.Second
+
+type clientConfig struct {
+	timeout   time.Duration
+	transport http.RoundTripper
+	tlsConfig *tls.Config
+	proxy     func(*http.Request) (*url.URL, error)
+	err       error
+}
+
+type ClientOption func(*clientConfig)
+
+// WithTimeout sets the timeout of every request, zero means no timeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithTransport sets the transport used to send requests. TLS and proxy
+// options are only applied when the transport is an *http.Transport.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *clientConfig) {
+		c.transport = transport
+	}
+}
+
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(c *clientConfig) {
+		c.tlsConfig = tlsConfig.Clone()
+	}
+}
+
+// WithRootCAFile trusts the PEM encoded certificates in filename in addition
+// to the system certificates, e.g. for local stacks with self-signed certificates.
+func WithRootCAFile(filename string) ClientOption {
+	return func(c *clientConfig) {
+		pem, err := os.ReadFile(filename)
+		if err != nil {
+			c.err = errors.Wrapf(err, "failed to read CA file: %s", filename)
+			return
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			c.err = errors.Errorf("no certificates found in CA file: %s", filename)
+			return
+		}
+
+		c.tls().RootCAs = pool
+	}
+}
+
+// WithInsecureSkipVerify disables verification of server certificates, it
+// should only be used against local stacks.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *clientConfig) {
+		c.tls().InsecureSkipVerify = true // nolint: gosec
+	}
+}
+
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *clientConfig) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			c.err = errors.Wrapf(err, "invalid proxy url: %s", proxyURL)
+			return
+		}
+
+		c.proxy = http.ProxyURL(u)
+	}
+}
+
+func (c *clientConfig) tls() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{} // nolint: gosec
+	}
+
+	return c.tlsConfig
+}
+
+// NewClient creates a Datadog traced client. The client keeps its
+// connections alive, so create it once and reuse it for all requests.
+func NewClient(opts ...ClientOption) (*http.Client, error) {
+	config := clientConfig{
+		timeout: DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.err != nil {
+		return nil, config.err
+	}
+
+	transport := config.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if t, ok := transport.(*http.Transport); ok {
+		t = t.Clone()
+
+		if config.tlsConfig != nil {
+			t.TLSClientConfig = config.tlsConfig
+		}
+
+		if config.proxy != nil {
+			t.Proxy = config.proxy
+		}
+
+		transport = t
+	}
+
+	return dd_http.WrapClient(
+		&http.Client{
+			Transport: transport,
+			Timeout:   config.timeout,
+		},
+		dd_http.RTWithResourceNamer(func(req *http.Request) string {
+			return fmt.Sprintf("%s %s", req.Method, req.URL.String())
+		}),
+	), nil
+}
+
+var (
+	defaultClient     *http.Client
+	defaultClientOnce sync.Once
+)
+
+// DefaultClient returns the client shared by BaseFeature and HttpClient
+// when no client has been set, it has no timeout.
+func DefaultClient() *http.Client {
+	defaultClientOnce.Do(func() {
+		// NewClient can only fail on invalid options
+		defaultClient, _ = NewClient(WithTimeout(0))
+	})
+
+	return defaultClient
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	requests int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTLSServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintln(rw, `{"key":"value"}`)
+	}))
+}
+
+func TestNewClient_RootCAFile(t *testing.T) {
+	ts := newTLSServer()
+	defer ts.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+
+	client, err := NewClient(WithRootCAFile(caFile))
+	require.NoError(t, err)
+
+	c := New()
+	c.SetHTTPClient(client)
+
+	resp, err := c.Get(ts.URL, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = New().Get(ts.URL, nil)
+	require.Error(t, err)
+}
+
+func TestNewClient_InsecureSkipVerify(t *testing.T) {
+	ts := newTLSServer()
+	defer ts.Close()
+
+	client, err := NewClient(WithInsecureSkipVerify())
+	require.NoError(t, err)
+
+	c := New()
+	c.SetHTTPClient(client)
+
+	resp, err := c.Get(ts.URL, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewClient_Transport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer ts.Close()
+
+	transport := &countingTransport{}
+
+	client, err := NewClient(WithTransport(transport))
+	require.NoError(t, err)
+
+	c := New()
+	c.SetHTTPClient(client)
+
+	_, err = c.Get(ts.URL, nil)
+	require.NoError(t, err)
+	_, err = c.Delete(ts.URL, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&transport.requests))
+}
+
+func TestNewClient_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithTimeout(10 * time.Millisecond))
+	require.NoError(t, err)
+
+	c := New()
+	c.SetHTTPClient(client)
+
+	_, err = c.Get(ts.URL, nil)
+	require.Error(t, err)
+}
+
+func TestDefaultClient_NoTimeout(t *testing.T) {
+	require.Zero(t, DefaultClient().Timeout)
+
+	client, err := NewClient()
+	require.NoError(t, err)
+	require.Equal(t, DefaultTimeout, client.Timeout)
+}
+
+func TestNewClient_InvalidOptions(t *testing.T) {
+	_, err := NewClient(WithRootCAFile("missing.pem"))
+	require.Error(t, err)
+
+	_, err = NewClient(WithProxy("://invalid"))
+	require.Error(t, err)
+}
@@ -9,11 +9,11 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
-	dd_http "gopkg.in/DataDog/dd-trace-go.v1/contrib/net/http"
 )
 
 type HttpClient struct {
-	token string
+	token  string
+	client *http.Client
 }
 
 type HttpResponse struct {
@@ -31,6 +31,19 @@ func NewWithToken(token string) *HttpClient {
 	return &HttpClient{token: token}
 }
 
+// SetHTTPClient sets the client used to send requests, see NewClient.
+func (c *HttpClient) SetHTTPClient(client *http.Client) {
+	c.client = client
+}
+
+func (c *HttpClient) httpClient() *http.Client {
+	if c.client == nil {
+		return DefaultClient()
+	}
+
+	return c.client
+}
+
 func (c *HttpClient) FetchToken(stage, username, password string) error {
 	return c.FetchTokenWithContext(context.Background(), stage, username, password)
 }
@@ -64,14 +77,7 @@ func (c *HttpClient) FetchTokenWithContext(ctx context.Context, stage, username,
 	req.Header.Set("accept", "application/json")
 	req.Header.Set("content-type", "application/json")
 
-	client := dd_http.WrapClient(
-		&http.Client{},
-		dd_http.RTWithResourceNamer(func(req *http.Request) string {
-			return fmt.Sprintf("%s %s", req.Method, req.URL.String())
-		}),
-	)
-
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return errors.Wrapf(err, "POST request to endpoint: %s failed", url)
 	}
@@ -143,13 +149,7 @@ func (c *HttpClient) send(ctx context.Context, method, url string, in interface{
 		req.Header.Set("content-type", "application/json")
 	}
 
-	client := dd_http.WrapClient(
-		&http.Client{},
-		dd_http.RTWithResourceNamer(func(req *http.Request) string {
-			return fmt.Sprintf("%s %s", req.Method, req.URL.String())
-		}),
-	)
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s request to endpoint: %s failed", method, url)
 	}
@@ -0,0 +1,55 @@
+// Package scenario names the files kept per scenario, such as cassettes,
+// snapshots and request dumps.
+package scenario
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strings"
+
+	"github.com/cucumber/godog"
+)
+
+// Feature returns the name of the feature file of s without its extension.
+func Feature(s *godog.Scenario) string {
+	return strings.TrimSuffix(filepath.Base(s.Uri), filepath.Ext(s.Uri))
+}
+
+// Name returns the name of s, the rows of a scenario outline share it so the
+// hash of their steps is appended. Pickle IDs aren't used since they change
+// when other features are added.
+func Name(s *godog.Scenario) string {
+	// Pickles of outline rows refer to the scenario and the examples row
+	if len(s.AstNodeIds) > 1 {
+		return s.Name + "_" + stepsHash(s.Steps)
+	}
+
+	return s.Name
+}
+
+func stepsHash(steps []*godog.Step) string {
+	hash := fnv.New32a()
+
+	for _, step := range steps {
+		fmt.Fprintln(hash, step.Text)
+
+		if step.Argument == nil {
+			continue
+		}
+
+		if step.Argument.DocString != nil {
+			fmt.Fprintln(hash, step.Argument.DocString.Content)
+		}
+
+		if step.Argument.DataTable != nil {
+			for _, row := range step.Argument.DataTable.Rows {
+				for _, cell := range row.Cells {
+					fmt.Fprintln(hash, cell.Value)
+				}
+			}
+		}
+	}
+
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
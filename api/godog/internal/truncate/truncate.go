@@ -0,0 +1,25 @@
+// Package truncate shortens response bodies shown in reports and errors.
+package truncate
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Body returns body cut to at most max bytes followed by how much of it is
+// shown, or body as it is when it isn't longer than max. A multi-byte
+// character is never cut in half.
+func Body(body []byte, max int) []byte {
+	if len(body) <= max {
+		return body
+	}
+
+	cut := max
+	for cut > 0 && cut > max-utf8.UTFMax && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	truncated := append([]byte{}, body[:cut]...)
+
+	return append(truncated, fmt.Sprintf("... (truncated, %d of %d bytes shown)", cut, len(body))...)
+}
@@ -0,0 +1,116 @@
+package json
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// Predicate checks a single element of an array, see Where.
+type Predicate func(element []byte) error
+
+// Where is a Predicate comparing the value at path inside an element, e.g.
+// Where(".name", OpEqual, "Pump 1"). Use "." for arrays of scalars.
+func Where(path string, op Operator, operand string) Predicate {
+	return func(element []byte) error {
+		return Compare(element, path, op, operand)
+	}
+}
+
+// AnyMatch checks that some element of the array at path satisfies
+// predicate and returns the index of the first one that does.
+func AnyMatch(json []byte, path string, predicate Predicate) (index int, err error) {
+	elements, err := readArray(json, path)
+	if err != nil {
+		return -1, err
+	}
+
+	var firstErr error
+
+	for idx, element := range elements {
+		err = predicate([]byte(element.Raw))
+		if err == nil {
+			return idx, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr == nil {
+		return -1, errors.Errorf("Match error: Expected some element to match, %s is empty", path)
+	}
+
+	return -1, errors.Wrapf(firstErr, "Match error: None of the %d elements at %s matches, first mismatch", len(elements), path)
+}
+
+// AllMatch checks that every element of the array at path satisfies predicate.
+func AllMatch(json []byte, path string, predicate Predicate) error {
+	elements, err := readArray(json, path)
+	if err != nil {
+		return err
+	}
+
+	for idx, element := range elements {
+		if err = predicate([]byte(element.Raw)); err != nil {
+			return errors.Wrapf(err, "Match error: Element %d at %s doesn't match", idx, path)
+		}
+	}
+
+	return nil
+}
+
+// NoneMatch checks that no element of the array at path satisfies predicate.
+func NoneMatch(json []byte, path string, predicate Predicate) error {
+	elements, err := readArray(json, path)
+	if err != nil {
+		return err
+	}
+
+	for idx, element := range elements {
+		if predicate([]byte(element.Raw)) == nil {
+			return errors.Errorf("Match error: Expected no element at %s to match, element %d does: %s", path, idx, element.Raw)
+		}
+	}
+
+	return nil
+}
+
+// Contains checks that the array at path has an element containing subset,
+// i.e. objects may have more keys than subset, and returns the index of the
+// first one. Placeholders such as "<uuid>" can be used, see Equal.
+func Contains(json []byte, path string, subset []byte) (index int, err error) {
+	expected, err := decode(subset)
+	if err != nil {
+		return -1, errors.Wrapf(err, "Match error: failed to parse subset: %s", string(subset))
+	}
+
+	index, err = AnyMatch(json, path, func(element []byte) error {
+		actual, err := decode(element)
+		if err != nil {
+			return err
+		}
+
+		config := equalConfig{partial: true}
+		if diffs := config.compare(nil, actual, expected); len(diffs) > 0 {
+			return errors.New(config.format(diffs[0]))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return -1, errors.Wrapf(err, "Match error: Expected an element of %s containing %s", path, string(subset))
+	}
+
+	return index, nil
+}
+
+func readArray(json []byte, path string) ([]gjson.Result, error) {
+	res := gjson.GetBytes(json, revertLegacySyntax(path))
+
+	if !res.IsArray() {
+		return nil, errors.Errorf("Match error: Expected an array got: %s, JSON: %s", res.String(), string(json))
+	}
+
+	return res.Array(), nil
+}
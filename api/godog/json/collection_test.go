@@ -0,0 +1,77 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const collectionJSON = `{
+	"data": {
+		"nodes": [
+			{"id": "0b1e3f6a-0c5e-4f5e-8d5b-3f3c3a1c9b1e", "name": "Pump 1", "type": "asset", "weight": 10},
+			{"id": "4c6a3e64-6b0e-4a4f-9f7a-2b1d3c4e5f60", "name": "Pump 2", "type": "asset", "weight": 20}
+		],
+		"tags": ["a", "b"],
+		"empty": []
+	}
+}`
+
+func TestAnyMatch(t *testing.T) {
+	json := []byte(collectionJSON)
+
+	index, err := AnyMatch(json, ".data.nodes", Where(".name", OpEqual, "Pump 2"))
+	require.NoError(t, err)
+	require.Equal(t, 1, index)
+
+	index, err = AnyMatch(json, ".data.tags", Where(".", OpEqual, "a"))
+	require.NoError(t, err)
+	require.Equal(t, 0, index)
+
+	_, err = AnyMatch(json, ".data.nodes", Where(".weight", OpGreater, "20"))
+	require.EqualError(t, err, "Match error: None of the 2 elements at .data.nodes matches, first mismatch: Match error: Expected 10 > 20")
+
+	_, err = AnyMatch(json, ".data.empty", Where(".", OpEqual, "a"))
+	require.EqualError(t, err, "Match error: Expected some element to match, .data.empty is empty")
+
+	_, err = AnyMatch(json, ".data.missing", Where(".", OpEqual, "a"))
+	require.Error(t, err)
+}
+
+func TestAllMatch(t *testing.T) {
+	json := []byte(collectionJSON)
+
+	assert.NoError(t, AllMatch(json, ".data.nodes", Where(".type", OpEqual, "asset")))
+	assert.NoError(t, AllMatch(json, ".data.empty", Where(".type", OpEqual, "asset")))
+	assert.EqualError(t, AllMatch(json, ".data.nodes", Where(".weight", OpLess, "20")),
+		"Match error: Element 1 at .data.nodes doesn't match: Match error: Expected 20 < 20")
+}
+
+func TestNoneMatch(t *testing.T) {
+	json := []byte(collectionJSON)
+
+	assert.NoError(t, NoneMatch(json, ".data.nodes", Where(".type", OpEqual, "hierarchy")))
+	assert.NoError(t, NoneMatch(json, ".data.nodes", Where(".missing", OpEqual, "")))
+	assert.EqualError(t, NoneMatch(json, ".data.tags", Where(".", OpEqual, "b")),
+		`Match error: Expected no element at .data.tags to match, element 1 does: "b"`)
+}
+
+func TestContains(t *testing.T) {
+	json := []byte(collectionJSON)
+
+	index, err := Contains(json, ".data.nodes", []byte(`{"id": "<uuid>", "name": "Pump 2"}`))
+	require.NoError(t, err)
+	require.Equal(t, 1, index)
+
+	index, err = Contains(json, ".data.tags", []byte(`"b"`))
+	require.NoError(t, err)
+	require.Equal(t, 1, index)
+
+	_, err = Contains(json, ".data.nodes", []byte(`{"name": "Pump 3"}`))
+	require.ErrorContains(t, err, `Match error: Expected an element of .data.nodes containing {"name": "Pump 3"}`)
+	require.ErrorContains(t, err, `expected "Pump 3" got "Pump 1"`)
+
+	_, err = Contains(json, ".data.nodes", []byte(`{`))
+	require.Error(t, err)
+}
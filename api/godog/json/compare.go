@@ -0,0 +1,361 @@
+package json
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// Operator compares the value at a path with an operand, see Compare.
+type Operator string
+
+const (
+	OpEqual          Operator = "=="
+	OpNotEqual       Operator = "!="
+	OpGreater        Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+	OpLess           Operator = "<"
+	OpLessOrEqual    Operator = "<="
+	OpContains       Operator = "contains"
+	OpHasPrefix      Operator = "starts with"
+	OpHasSuffix      Operator = "ends with"
+	OpMatches        Operator = "matches"
+	OpBefore         Operator = "before"
+	OpAfter          Operator = "after"
+)
+
+// Compare applies op to the value at path and operand. Numeric operators
+// require a number, string operators a string and time operators an RFC 3339
+// timestamp, the operand of a time operator can also be `now`.
+func Compare(json []byte, path string, op Operator, operand string) error {
+	res, err := read(json, path)
+	if err != nil {
+		return err
+	}
+
+	return compareResult(res, op, operand)
+}
+
+func compareResult(res gjson.Result, op Operator, operand string) error {
+	switch op {
+	case OpEqual:
+		if res.String() != operand {
+			return errors.Errorf("Match error: Expected '%s' to equal '%s'", res.String(), operand)
+		}
+	case OpNotEqual:
+		if res.String() == operand {
+			return errors.Errorf("Match error: Expected '%s' to not equal '%s'", res.String(), operand)
+		}
+	case OpGreater, OpGreaterOrEqual, OpLess, OpLessOrEqual:
+		return compareNumber(res, op, operand)
+	case OpContains, OpHasPrefix, OpHasSuffix, OpMatches:
+		return compareString(res, op, operand)
+	case OpBefore, OpAfter:
+		return compareTime(res, op, operand)
+	default:
+		return errors.Errorf("Match error: Unknown operator '%s'", op)
+	}
+
+	return nil
+}
+
+func compareNumber(res gjson.Result, op Operator, operand string) error {
+	expected, err := strconv.ParseFloat(operand, 64)
+	if err != nil {
+		return errors.Wrapf(err, "Match error: Expected a numeric operand got '%s'", operand)
+	}
+
+	if res.Type != gjson.Number {
+		return errors.Errorf("Match error: Expected a number got '%s'", res.Raw)
+	}
+
+	actual := res.Float()
+
+	ok := map[Operator]bool{
+		OpGreater:        actual > expected,
+		OpGreaterOrEqual: actual >= expected,
+		OpLess:           actual < expected,
+		OpLessOrEqual:    actual <= expected,
+	}[op]
+
+	if !ok {
+		return errors.Errorf("Match error: Expected %s %s %s", res.Raw, op, operand)
+	}
+
+	return nil
+}
+
+func compareString(res gjson.Result, op Operator, operand string) error {
+	if res.Type != gjson.String {
+		return errors.Errorf("Match error: Expected a string got '%s'", res.Raw)
+	}
+
+	actual := res.String()
+
+	var ok bool
+
+	switch op {
+	case OpContains:
+		ok = strings.Contains(actual, operand)
+	case OpHasPrefix:
+		ok = strings.HasPrefix(actual, operand)
+	case OpHasSuffix:
+		ok = strings.HasSuffix(actual, operand)
+	case OpMatches:
+		re, err := regexp.Compile(operand)
+		if err != nil {
+			return errors.Wrap(err, "Failed to compile regexp")
+		}
+
+		ok = re.MatchString(actual)
+	}
+
+	if !ok {
+		return errors.Errorf("Match error: Expected '%s' %s '%s'", actual, op, operand)
+	}
+
+	return nil
+}
+
+func compareTime(res gjson.Result, op Operator, operand string) error {
+	actual, err := parseTime(res)
+	if err != nil {
+		return err
+	}
+
+	expected := time.Now()
+	if operand != "now" {
+		if expected, err = time.Parse(time.RFC3339Nano, operand); err != nil {
+			return errors.Wrapf(err, "Match error: Expected an RFC 3339 operand got '%s'", operand)
+		}
+	}
+
+	if (op == OpBefore && !actual.Before(expected)) || (op == OpAfter && !actual.After(expected)) {
+		return errors.Errorf("Match error: Expected %s to be %s %s", actual.Format(time.RFC3339Nano), op, expected.Format(time.RFC3339Nano))
+	}
+
+	return nil
+}
+
+func parseTime(res gjson.Result) (time.Time, error) {
+	if res.Type != gjson.String {
+		return time.Time{}, errors.Errorf("Match error: Expected a timestamp got '%s'", res.Raw)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, res.String())
+	if err != nil {
+		return time.Time{}, errors.Errorf("Match error: Expected an RFC 3339 timestamp got '%s'", res.String())
+	}
+
+	return t, nil
+}
+
+func GreaterThan(json []byte, path string, value float64) error {
+	return Compare(json, path, OpGreater, formatFloat(value))
+}
+
+func GreaterThanOrEqual(json []byte, path string, value float64) error {
+	return Compare(json, path, OpGreaterOrEqual, formatFloat(value))
+}
+
+func LessThan(json []byte, path string, value float64) error {
+	return Compare(json, path, OpLess, formatFloat(value))
+}
+
+func LessThanOrEqual(json []byte, path string, value float64) error {
+	return Compare(json, path, OpLessOrEqual, formatFloat(value))
+}
+
+// Between checks that the number at path is within min and max, inclusive.
+func Between(json []byte, path string, min, max float64) error {
+	if err := GreaterThanOrEqual(json, path, min); err != nil {
+		return err
+	}
+
+	return LessThanOrEqual(json, path, max)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func StringContains(json []byte, path string, substring string) error {
+	return Compare(json, path, OpContains, substring)
+}
+
+func HasPrefix(json []byte, path string, prefix string) error {
+	return Compare(json, path, OpHasPrefix, prefix)
+}
+
+func HasSuffix(json []byte, path string, suffix string) error {
+	return Compare(json, path, OpHasSuffix, suffix)
+}
+
+func Before(json []byte, path string, t time.Time) error {
+	return Compare(json, path, OpBefore, t.Format(time.RFC3339Nano))
+}
+
+func After(json []byte, path string, t time.Time) error {
+	return Compare(json, path, OpAfter, t.Format(time.RFC3339Nano))
+}
+
+// WithinDuration checks that the timestamp at path differs at most delta from t.
+func WithinDuration(json []byte, path string, t time.Time, delta time.Duration) error {
+	res, err := read(json, path)
+	if err != nil {
+		return err
+	}
+
+	actual, err := parseTime(res)
+	if err != nil {
+		return err
+	}
+
+	if diff := actual.Sub(t); diff < -delta || diff > delta {
+		return errors.Errorf("Match error: Expected %s to be within %s of %s, difference: %s",
+			actual.Format(time.RFC3339Nano), delta, t.Format(time.RFC3339Nano), diff)
+	}
+
+	return nil
+}
+
+// Kind is the type or format of a value, see IsKind.
+type Kind string
+
+const (
+	KindNull      Kind = "null"
+	KindNumber    Kind = "number"
+	KindString    Kind = "string"
+	KindBool      Kind = "boolean"
+	KindArray     Kind = "array"
+	KindObject    Kind = "object"
+	KindUUID      Kind = "uuid"
+	KindEmail     Kind = "email"
+	KindURL       Kind = "url"
+	KindTimestamp Kind = "timestamp"
+)
+
+// IsKind checks that the value at path is of kind.
+func IsKind(json []byte, path string, kind Kind) error {
+	res, err := read(json, path)
+	if err != nil {
+		return err
+	}
+
+	return isKind(res, kind)
+}
+
+func isKind(res gjson.Result, kind Kind) error {
+	var ok bool
+
+	switch kind {
+	case KindNull:
+		ok = res.Type == gjson.Null
+	case KindNumber:
+		ok = res.Type == gjson.Number
+	case KindString:
+		ok = res.Type == gjson.String
+	case KindBool:
+		ok = res.IsBool()
+	case KindArray:
+		ok = res.IsArray()
+	case KindObject:
+		ok = res.IsObject()
+	case KindUUID:
+		_, err := uuid.Parse(res.String())
+		ok = res.Type == gjson.String && err == nil
+	case KindEmail:
+		address, err := mail.ParseAddress(res.String())
+		ok = res.Type == gjson.String && err == nil && address.Address == res.String()
+	case KindURL:
+		u, err := url.Parse(res.String())
+		ok = res.Type == gjson.String && err == nil && u.Scheme != "" && u.Host != ""
+	case KindTimestamp:
+		_, err := parseTime(res)
+		ok = err == nil
+	default:
+		return errors.Errorf("Match error: Unknown kind '%s'", kind)
+	}
+
+	if !ok {
+		return errors.Errorf("Match error: Expected a value of kind '%s' got '%s'", kind, res.Raw)
+	}
+
+	return nil
+}
+
+func IsNumber(json []byte, path string) error {
+	return IsKind(json, path, KindNumber)
+}
+
+func IsString(json []byte, path string) error {
+	return IsKind(json, path, KindString)
+}
+
+func IsBool(json []byte, path string) error {
+	return IsKind(json, path, KindBool)
+}
+
+func IsArray(json []byte, path string) error {
+	return IsKind(json, path, KindArray)
+}
+
+func IsObject(json []byte, path string) error {
+	return IsKind(json, path, KindObject)
+}
+
+func IsUUID(json []byte, path string) error {
+	return IsKind(json, path, KindUUID)
+}
+
+func IsEmail(json []byte, path string) error {
+	return IsKind(json, path, KindEmail)
+}
+
+func IsURL(json []byte, path string) error {
+	return IsKind(json, path, KindURL)
+}
+
+// ArrayLenBetween checks that the array at path has min to max elements, inclusive.
+func ArrayLenBetween(json []byte, path string, min, max int) error {
+	path = revertLegacySyntax(path)
+
+	res := gjson.GetBytes(json, path)
+
+	if !res.IsArray() {
+		return errors.Errorf("Match error: Expected an array got: %s, JSON: %s", res.String(), string(json))
+	}
+
+	if length := len(res.Array()); length < min || length > max {
+		return errors.Errorf("Match error: Expected an array of length between %d and %d got: %d JSON: %s", min, max, length, string(json))
+	}
+
+	return nil
+}
+
+func ArrayLenAtLeast(json []byte, path string, min int) error {
+	return ArrayLenBetween(json, path, min, int(^uint(0)>>1))
+}
+
+func ArrayLenAtMost(json []byte, path string, max int) error {
+	return ArrayLenBetween(json, path, 0, max)
+}
+
+func read(json []byte, path string) (gjson.Result, error) {
+	path = revertLegacySyntax(path)
+
+	res := gjson.GetBytes(json, path)
+
+	if !res.Exists() {
+		return res, errors.Errorf("Match error: Expected path to be present, missing path: %s JSON: %s", path, string(json))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package json
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const compareJSON = `{
+	"data": {
+		"id": "0b1e3f6a-0c5e-4f5e-8d5b-3f3c3a1c9b1e",
+		"name": "Pump 1",
+		"count": 3,
+		"ratio": 0.5,
+		"active": true,
+		"parent": null,
+		"email": "pump@example.com",
+		"link": "https://example.com/nodes/1",
+		"createdAt": "2024-01-02T03:04:05Z",
+		"tags": ["a", "b", "c"]
+	}
+}`
+
+func TestCompare(t *testing.T) {
+	json := []byte(compareJSON)
+
+	assert.NoError(t, Compare(json, ".data.name", OpEqual, "Pump 1"))
+	assert.NoError(t, Compare(json, ".data.name", OpNotEqual, "Pump 2"))
+	assert.NoError(t, Compare(json, ".data.count", OpGreater, "2"))
+	assert.NoError(t, Compare(json, ".data.count", OpGreaterOrEqual, "3"))
+	assert.NoError(t, Compare(json, ".data.ratio", OpLess, "1"))
+	assert.NoError(t, Compare(json, ".data.ratio", OpLessOrEqual, "0.5"))
+	assert.NoError(t, Compare(json, ".data.name", OpContains, "mp"))
+	assert.NoError(t, Compare(json, ".data.name", OpHasPrefix, "Pump"))
+	assert.NoError(t, Compare(json, ".data.name", OpHasSuffix, " 1"))
+	assert.NoError(t, Compare(json, ".data.name", OpMatches, `^Pump \d$`))
+	assert.NoError(t, Compare(json, ".data.createdAt", OpBefore, "now"))
+	assert.NoError(t, Compare(json, ".data.createdAt", OpAfter, "2024-01-01T00:00:00Z"))
+
+	assert.EqualError(t, Compare(json, ".data.count", OpGreater, "3"), "Match error: Expected 3 > 3")
+	assert.EqualError(t, Compare(json, ".data.name", OpGreater, "3"), `Match error: Expected a number got '"Pump 1"'`)
+	assert.EqualError(t, Compare(json, ".data.count", OpContains, "3"), "Match error: Expected a string got '3'")
+	assert.EqualError(t, Compare(json, ".data.name", OpHasPrefix, "Fan"), "Match error: Expected 'Pump 1' starts with 'Fan'")
+	assert.Error(t, Compare(json, ".data.createdAt", OpAfter, "now"))
+	assert.Error(t, Compare(json, ".data.count", OpGreater, "many"))
+	assert.Error(t, Compare(json, ".data.count", "~", "3"))
+	assert.Error(t, Compare(json, ".data.missing", OpEqual, ""))
+}
+
+func TestNumericComparisons(t *testing.T) {
+	json := []byte(compareJSON)
+
+	assert.NoError(t, GreaterThan(json, ".data.count", 2.5))
+	assert.NoError(t, GreaterThanOrEqual(json, ".data.count", 3))
+	assert.NoError(t, LessThan(json, ".data.count", 4))
+	assert.NoError(t, LessThanOrEqual(json, ".data.count", 3))
+	assert.NoError(t, Between(json, ".data.ratio", 0, 1))
+	assert.Error(t, Between(json, ".data.ratio", 0.6, 1))
+	assert.Error(t, Between(json, ".data.ratio", 0, 0.4))
+}
+
+func TestStringComparisons(t *testing.T) {
+	json := []byte(compareJSON)
+
+	assert.NoError(t, StringContains(json, ".data.email", "@"))
+	assert.NoError(t, HasPrefix(json, ".data.link", "https://"))
+	assert.NoError(t, HasSuffix(json, ".data.link", "/1"))
+	assert.Error(t, HasSuffix(json, ".data.link", "/2"))
+}
+
+func TestTimeComparisons(t *testing.T) {
+	json := []byte(compareJSON)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.NoError(t, Before(json, ".data.createdAt", createdAt.Add(time.Second)))
+	assert.NoError(t, After(json, ".data.createdAt", createdAt.Add(-time.Second)))
+	assert.Error(t, After(json, ".data.createdAt", createdAt))
+	assert.NoError(t, WithinDuration(json, ".data.createdAt", createdAt.Add(time.Minute), time.Minute))
+	assert.NoError(t, WithinDuration(json, ".data.createdAt", createdAt.Add(-time.Minute), time.Minute))
+	assert.Error(t, WithinDuration(json, ".data.createdAt", time.Now(), time.Hour))
+	assert.Error(t, WithinDuration(json, ".data.name", time.Now(), time.Hour))
+}
+
+func TestIsKind(t *testing.T) {
+	json := []byte(compareJSON)
+
+	assert.NoError(t, IsKind(json, ".data.parent", KindNull))
+	assert.NoError(t, IsNumber(json, ".data.count"))
+	assert.NoError(t, IsString(json, ".data.name"))
+	assert.NoError(t, IsBool(json, ".data.active"))
+	assert.NoError(t, IsArray(json, ".data.tags"))
+	assert.NoError(t, IsObject(json, ".data"))
+	assert.NoError(t, IsUUID(json, ".data.id"))
+	assert.NoError(t, IsEmail(json, ".data.email"))
+	assert.NoError(t, IsURL(json, ".data.link"))
+	assert.NoError(t, IsKind(json, ".data.createdAt", KindTimestamp))
+
+	assert.EqualError(t, IsNumber(json, ".data.name"), `Match error: Expected a value of kind 'number' got '"Pump 1"'`)
+	assert.Error(t, IsUUID(json, ".data.name"))
+	assert.Error(t, IsEmail(json, ".data.name"))
+	assert.Error(t, IsURL(json, ".data.name"))
+	assert.Error(t, IsKind(json, ".data.name", "color"))
+}
+
+func TestArrayLenRanges(t *testing.T) {
+	json := []byte(compareJSON)
+
+	require.NoError(t, ArrayLenBetween(json, ".data.tags", 1, 3))
+	require.NoError(t, ArrayLenAtLeast(json, ".data.tags", 3))
+	require.NoError(t, ArrayLenAtMost(json, ".data.tags", 3))
+	require.Error(t, ArrayLenAtLeast(json, ".data.tags", 4))
+	require.Error(t, ArrayLenAtMost(json, ".data.tags", 2))
+	require.Error(t, ArrayLenBetween(json, ".data.name", 0, 10))
+}
@@ -0,0 +1,437 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorReset  = "\033[0m"
+)
+
+type equalConfig struct {
+	ignored      [][]string
+	unordered    [][]string
+	unorderedAll bool
+	tolerance    float64
+	color        bool
+	// partial comparisons allow objects to have keys that aren't expected
+	partial bool
+}
+
+type EqualOption func(*equalConfig)
+
+// IgnorePaths skips the values at paths, e.g. `.data.createdAt`. A `*`
+// segment matches any key or index, e.g. `.data[*].id` or `.data.*.id`.
+func IgnorePaths(paths ...string) EqualOption {
+	return func(c *equalConfig) {
+		for _, path := range paths {
+			c.ignored = append(c.ignored, pathSegments(path))
+		}
+	}
+}
+
+// IgnoreArrayOrder compares the arrays at paths regardless of the order of
+// their elements, all arrays when no path is given.
+func IgnoreArrayOrder(paths ...string) EqualOption {
+	return func(c *equalConfig) {
+		if len(paths) == 0 {
+			c.unorderedAll = true
+		}
+
+		for _, path := range paths {
+			c.unordered = append(c.unordered, pathSegments(path))
+		}
+	}
+}
+
+// NumericTolerance makes numbers equal when they differ by at most tolerance.
+func NumericTolerance(tolerance float64) EqualOption {
+	return func(c *equalConfig) {
+		c.tolerance = tolerance
+	}
+}
+
+// WithColor turns the ANSI colors of the difference report on or off, they
+// are off by default.
+func WithColor(enabled bool) EqualOption {
+	return func(c *equalConfig) {
+		c.color = enabled
+	}
+}
+
+// Equal compares the whole actual document with expected. String values in
+// expected can be placeholders matching any value of a kind:
+//
+//	"<any>"            any value, including null
+//	"<string>"         any string
+//	"<number>"         any number
+//	"<bool>"           true or false
+//	"<uuid>"           a UUID string
+//	"<timestamp>"      an RFC 3339 timestamp string
+//	"<regex:pattern>"  a string matching pattern
+//
+// On mismatch the error lists every difference with its path.
+func Equal(actual, expected []byte, opts ...EqualOption) error {
+	var config equalConfig
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	actualValue, err := decode(actual)
+	if err != nil {
+		return errors.Wrapf(err, "Equal error: failed to parse actual JSON: %s", string(actual))
+	}
+
+	expectedValue, err := decode(expected)
+	if err != nil {
+		return errors.Wrapf(err, "Equal error: failed to parse expected JSON: %s", string(expected))
+	}
+
+	diffs := config.compare(nil, actualValue, expectedValue)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(diffs))
+	for idx, d := range diffs {
+		lines[idx] = config.format(d)
+	}
+
+	return errors.Errorf("Equal error: %d difference(s):\n\t%s", len(diffs), strings.Join(lines, "\n\t"))
+}
+
+func decode(data []byte) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&value)
+
+	return value, err
+}
+
+type difference struct {
+	path     []string
+	expected interface{}
+	actual   interface{}
+	message  string
+}
+
+func (c equalConfig) format(d difference) string {
+	paint := func(color, text string) string {
+		if !c.color {
+			return text
+		}
+
+		return color + text + colorReset
+	}
+
+	path := paint(colorYellow, legacyPath(d.path))
+
+	if d.message != "" {
+		return fmt.Sprintf("%s: %s", path, d.message)
+	}
+
+	return fmt.Sprintf("%s: expected %s got %s", path, paint(colorGreen, encode(d.expected)), paint(colorRed, encode(d.actual)))
+}
+
+func encode(value interface{}) string {
+	var encoded bytes.Buffer
+
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return strings.TrimSuffix(encoded.String(), "\n")
+}
+
+func (c equalConfig) compare(path []string, actual, expected interface{}) []difference {
+	if matchesAny(c.ignored, path) {
+		return nil
+	}
+
+	if placeholder, ok := expected.(string); ok && isPlaceholder(placeholder) {
+		// Redacted snapshots contain the placeholders themselves
+		if actual == placeholder {
+			return nil
+		}
+
+		if err := matchPlaceholder(placeholder, actual); err != nil {
+			return []difference{{path: path, message: fmt.Sprintf("expected %s got %s: %s", placeholder, encode(actual), err)}}
+		}
+
+		return nil
+	}
+
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actualObject, ok := actual.(map[string]interface{})
+		if !ok {
+			return []difference{{path: path, expected: expected, actual: actual}}
+		}
+
+		return c.compareObjects(path, actualObject, expected)
+	case []interface{}:
+		actualArray, ok := actual.([]interface{})
+		if !ok {
+			return []difference{{path: path, expected: expected, actual: actual}}
+		}
+
+		if c.unorderedAll || matchesAny(c.unordered, path) {
+			return c.compareUnordered(path, actualArray, expected)
+		}
+
+		return c.compareArrays(path, actualArray, expected)
+	case json.Number:
+		actualNumber, ok := actual.(json.Number)
+		if !ok || !c.numbersEqual(actualNumber, expected) {
+			return []difference{{path: path, expected: expected, actual: actual}}
+		}
+
+		return nil
+	}
+
+	if actual != expected {
+		return []difference{{path: path, expected: expected, actual: actual}}
+	}
+
+	return nil
+}
+
+func (c equalConfig) compareObjects(path []string, actual, expected map[string]interface{}) (diffs []difference) {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := appendPath(path, key)
+
+		actualValue, inActual := actual[key]
+		expectedValue, inExpected := expected[key]
+
+		switch {
+		case matchesAny(c.ignored, keyPath):
+		case !inActual:
+			diffs = append(diffs, difference{path: keyPath, message: fmt.Sprintf("missing, expected %s", encode(expectedValue))})
+		case !inExpected && c.partial:
+		case !inExpected:
+			diffs = append(diffs, difference{path: keyPath, message: fmt.Sprintf("unexpected %s", encode(actualValue))})
+		default:
+			diffs = append(diffs, c.compare(keyPath, actualValue, expectedValue)...)
+		}
+	}
+
+	return diffs
+}
+
+func (c equalConfig) compareArrays(path []string, actual, expected []interface{}) (diffs []difference) {
+	for idx := 0; idx < len(expected) || idx < len(actual); idx++ {
+		indexPath := appendPath(path, fmt.Sprint(idx))
+
+		switch {
+		case matchesAny(c.ignored, indexPath):
+		case idx >= len(actual):
+			diffs = append(diffs, difference{path: indexPath, message: fmt.Sprintf("missing, expected %s", encode(expected[idx]))})
+		case idx >= len(expected):
+			diffs = append(diffs, difference{path: indexPath, message: fmt.Sprintf("unexpected %s", encode(actual[idx]))})
+		default:
+			diffs = append(diffs, c.compare(indexPath, actual[idx], expected[idx])...)
+		}
+	}
+
+	return diffs
+}
+
+// compareUnordered pairs the expected with the actual elements so that as
+// many as possible are equal, and reports the elements left on either side.
+// Pairing the first equal element isn't enough since a placeholder can equal
+// an element another expected element needs.
+func (c equalConfig) compareUnordered(path []string, actual, expected []interface{}) (diffs []difference) {
+	equal := make([][]bool, len(expected))
+	for expectedIdx, expectedValue := range expected {
+		equal[expectedIdx] = make([]bool, len(actual))
+
+		for actualIdx, actualValue := range actual {
+			equal[expectedIdx][actualIdx] = len(c.compare(appendPath(path, fmt.Sprint(actualIdx)), actualValue, expectedValue)) == 0
+		}
+	}
+
+	// pairedWith holds the index of the expected element paired with an
+	// actual element, or -1
+	pairedWith := make([]int, len(actual))
+	for idx := range pairedWith {
+		pairedWith[idx] = -1
+	}
+
+	for expectedIdx, expectedValue := range expected {
+		if !pair(equal, pairedWith, expectedIdx, make([]bool, len(actual))) {
+			diffs = append(diffs, difference{
+				path:    appendPath(path, fmt.Sprint(expectedIdx)),
+				message: fmt.Sprintf("no element matches expected %s", encode(expectedValue)),
+			})
+		}
+	}
+
+	for actualIdx, actualValue := range actual {
+		if pairedWith[actualIdx] < 0 {
+			diffs = append(diffs, difference{
+				path:    appendPath(path, fmt.Sprint(actualIdx)),
+				message: fmt.Sprintf("unexpected %s", encode(actualValue)),
+			})
+		}
+	}
+
+	return diffs
+}
+
+// pair finds an actual element for the expected element, moving earlier
+// pairs to other actual elements when needed (an augmenting path of a
+// bipartite matching).
+func pair(equal [][]bool, pairedWith []int, expectedIdx int, visited []bool) bool {
+	for actualIdx, isEqual := range equal[expectedIdx] {
+		if !isEqual || visited[actualIdx] {
+			continue
+		}
+
+		visited[actualIdx] = true
+
+		if pairedWith[actualIdx] < 0 || pair(equal, pairedWith, pairedWith[actualIdx], visited) {
+			pairedWith[actualIdx] = expectedIdx
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c equalConfig) numbersEqual(actual, expected json.Number) bool {
+	if actual == expected {
+		return true
+	}
+
+	a, errA := actual.Float64()
+	b, errB := expected.Float64()
+
+	return errA == nil && errB == nil && math.Abs(a-b) <= c.tolerance
+}
+
+var placeholderRegexp = regexp.MustCompile(`^<(any|string|number|bool|uuid|timestamp|regex:.*)>$`)
+
+func isPlaceholder(value string) bool {
+	return placeholderRegexp.MatchString(value)
+}
+
+func matchPlaceholder(placeholder string, value interface{}) error {
+	kind := strings.TrimSuffix(strings.TrimPrefix(placeholder, "<"), ">")
+
+	if kind == "any" {
+		return nil
+	}
+
+	if kind == "number" {
+		if _, ok := value.(json.Number); !ok {
+			return errors.New("not a number")
+		}
+
+		return nil
+	}
+
+	if kind == "bool" {
+		if _, ok := value.(bool); !ok {
+			return errors.New("not a boolean")
+		}
+
+		return nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("not a string")
+	}
+
+	switch {
+	case kind == "uuid":
+		_, err := uuid.Parse(str)
+		return err
+	case kind == "timestamp":
+		_, err := time.Parse(time.RFC3339Nano, str)
+		return err
+	case strings.HasPrefix(kind, "regex:"):
+		re, err := regexp.Compile(strings.TrimPrefix(kind, "regex:"))
+		if err != nil {
+			return errors.Wrap(err, "invalid pattern")
+		}
+
+		if !re.MatchString(str) {
+			return errors.New("no match")
+		}
+	}
+
+	return nil
+}
+
+// pathSegments splits a path such as `.data[0].id` or `.data[*].id` into
+// its keys and indices.
+func pathSegments(path string) []string {
+	path = strings.ReplaceAll(path, "[*]", ".*")
+	path = revertLegacySyntax(path)
+
+	if path == "@this" {
+		return nil
+	}
+
+	return strings.Split(path, ".")
+}
+
+func appendPath(path []string, segment string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), segment)
+}
+
+func matchesAny(patterns [][]string, path []string) bool {
+	for _, pattern := range patterns {
+		if matchesPath(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+
+	for idx := range pattern {
+		if pattern[idx] != "*" && pattern[idx] != path[idx] {
+			return false
+		}
+	}
+
+	return true
+}
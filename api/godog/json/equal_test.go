@@ -0,0 +1,116 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const equalActual = `{
+	"data": {
+		"id": "0b1e3f6a-0c5e-4f5e-8d5b-3f3c3a1c9b1e",
+		"name": "Pump 1",
+		"createdAt": "2024-01-02T03:04:05Z",
+		"temperature": 20.04,
+		"tags": ["b", "a"],
+		"children": [{"id": 1}, {"id": 2}]
+	}
+}`
+
+func TestEqual(t *testing.T) {
+	expected := `{
+		"data": {
+			"id": "<uuid>",
+			"name": "<regex:^Pump \\d+$>",
+			"createdAt": "<timestamp>",
+			"temperature": "<number>",
+			"tags": ["b", "a"],
+			"children": "<any>"
+		}
+	}`
+
+	require.NoError(t, Equal([]byte(equalActual), []byte(expected)))
+}
+
+func TestEqual_Options(t *testing.T) {
+	expected := `{
+		"data": {
+			"id": "<uuid>",
+			"name": "Pump 1",
+			"temperature": 20,
+			"tags": ["a", "b"],
+			"children": [{"id": 2}, {"id": "<number>"}]
+		}
+	}`
+
+	err := Equal([]byte(equalActual), []byte(expected),
+		IgnorePaths(".data.createdAt"),
+		IgnoreArrayOrder(),
+		NumericTolerance(0.1),
+	)
+	require.NoError(t, err)
+
+	err = Equal([]byte(equalActual), []byte(expected),
+		IgnorePaths(".data.createdAt", ".data.children[*].id"),
+		IgnoreArrayOrder(".data.tags"),
+		NumericTolerance(0.05),
+	)
+	require.NoError(t, err)
+}
+
+func TestEqual_Differences(t *testing.T) {
+	expected := `{
+		"data": {
+			"id": "<uuid>",
+			"name": "Pump 2",
+			"temperature": 20.04,
+			"tags": ["a", "b", "c"],
+			"children": [{"id": 1}],
+			"parentId": "<string>"
+		}
+	}`
+
+	err := Equal([]byte(equalActual), []byte(expected), WithColor(false), IgnoreArrayOrder(".data.tags"))
+	require.EqualError(t, err, `Equal error: 5 difference(s):
+	.data.children[1]: unexpected {"id":2}
+	.data.createdAt: unexpected "2024-01-02T03:04:05Z"
+	.data.name: expected "Pump 2" got "Pump 1"
+	.data.parentId: missing, expected "<string>"
+	.data.tags[2]: no element matches expected "c"`)
+}
+
+func TestEqual_Placeholders(t *testing.T) {
+	err := Equal([]byte(`{"id":"not-a-uuid","count":"1","ok":1}`), []byte(`{"id":"<uuid>","count":"<number>","ok":"<bool>"}`), WithColor(false))
+	require.EqualError(t, err, `Equal error: 3 difference(s):
+	.count: expected <number> got "1": not a number
+	.id: expected <uuid> got "not-a-uuid": invalid UUID length: 10
+	.ok: expected <bool> got 1: not a boolean`)
+}
+
+func TestEqual_Color(t *testing.T) {
+	err := Equal([]byte(`{"a":1}`), []byte(`{"a":2}`), WithColor(true))
+	require.EqualError(t, err, "Equal error: 1 difference(s):\n\t\033[33m.a\033[0m: expected \033[32m2\033[0m got \033[31m1\033[0m")
+
+	err = Equal([]byte(`{"a":1}`), []byte(`{"a":2}`))
+	require.EqualError(t, err, "Equal error: 1 difference(s):\n\t.a: expected 2 got 1")
+}
+
+func TestEqual_UnorderedPlaceholders(t *testing.T) {
+	require.NoError(t, Equal([]byte(`["a", "b"]`), []byte(`["<string>", "a"]`), IgnoreArrayOrder()))
+	require.NoError(t, Equal([]byte(`[{"id": 2}, {"id": 1}]`), []byte(`[{"id": "<number>"}, {"id": 2}]`), IgnoreArrayOrder()))
+
+	err := Equal([]byte(`["a", "b", 1]`), []byte(`["<string>", "a", "c"]`), IgnoreArrayOrder())
+	require.EqualError(t, err, `Equal error: 2 difference(s):
+	[2]: no element matches expected "c"
+	[2]: unexpected 1`)
+}
+
+func TestEqual_Root(t *testing.T) {
+	require.NoError(t, Equal([]byte(`[1, 2]`), []byte(`[2, 1]`), IgnoreArrayOrder()))
+
+	err := Equal([]byte(`[1]`), []byte(`{}`), WithColor(false))
+	require.EqualError(t, err, "Equal error: 1 difference(s):\n\t.: expected {} got [1]")
+
+	err = Equal([]byte(`{`), []byte(`{}`))
+	require.ErrorContains(t, err, "Equal error: failed to parse actual JSON")
+}
@@ -102,6 +102,20 @@ func Read(json []byte, path string) (result string, err error) {
 	return res.String(), nil
 }
 
+// ReadValue returns the value at path decoded into its Go representation,
+// i.e. string, float64, bool, nil, []interface{} or map[string]interface{}.
+func ReadValue(json []byte, path string) (result interface{}, err error) {
+	path = revertLegacySyntax(path)
+
+	res := gjson.GetBytes(json, path)
+
+	if !res.Exists() {
+		return nil, errors.Errorf("Match error: Expected path to be present, missing path: %s JSON: %s", path, string(json))
+	}
+
+	return res.Value(), nil
+}
+
 func ReadStringArr(json []byte, path string) (result []string, err error) {
 	path = revertLegacySyntax(path)
 
@@ -92,6 +92,25 @@ func TestRead(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestReadValue(t *testing.T) {
+	json := []byte(`{"key" : {"number" : 98, "list": ["a", true], "null": null} }`)
+
+	result, err := ReadValue(json, ".key.number")
+	require.Nil(t, err)
+	require.Equal(t, float64(98), result)
+
+	result, err = ReadValue(json, ".key.list")
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"a", true}, result)
+
+	result, err = ReadValue(json, ".key.null")
+	require.Nil(t, err)
+	require.Nil(t, result)
+
+	_, err = ReadValue(json, ".key.missing")
+	require.NotNil(t, err)
+}
+
 func TestReadStringArr(t *testing.T) {
 	result, err := ReadStringArr([]byte(`{"key" : ["value1", "value2"]}`), ".key")
 	require.Nil(t, err)
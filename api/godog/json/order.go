@@ -0,0 +1,144 @@
+package json
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// Order is the direction an array is sorted in, see IsSorted.
+type Order string
+
+const (
+	Ascending  Order = "ascending"
+	Descending Order = "descending"
+)
+
+// IsSorted checks that the elements of the array at path are sorted by the
+// value at field, "." for arrays of scalars. Numbers are compared
+// numerically, RFC 3339 timestamps chronologically and other strings
+// lexicographically. Equal neighbours are allowed.
+func IsSorted(json []byte, path, field string, order Order) error {
+	if order != Ascending && order != Descending {
+		return errors.Errorf("Match error: Unknown order '%s'", order)
+	}
+
+	values, err := fieldValues(json, path, field)
+	if err != nil {
+		return err
+	}
+
+	compare, err := comparator(values)
+	if err != nil {
+		return err
+	}
+
+	for idx := 1; idx < len(values); idx++ {
+		c := compare(values[idx-1], values[idx])
+		if (order == Ascending && c > 0) || (order == Descending && c < 0) {
+			return errors.Errorf("Match error: Expected %s to be sorted by %s in %s order, element %d: %s, element %d: %s",
+				path, field, order, idx-1, values[idx-1].Raw, idx, values[idx].Raw)
+		}
+	}
+
+	return nil
+}
+
+// IsUnique checks that no two elements of the array at path have the same
+// value at field, "." for arrays of scalars. Values are compared as JSON, so
+// `1` equals `1.0` and the order of object keys doesn't matter.
+func IsUnique(json []byte, path, field string) error {
+	values, err := fieldValues(json, path, field)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]int, len(values))
+
+	for idx, value := range values {
+		key := uniqueKey(value)
+
+		if first, exists := seen[key]; exists {
+			return errors.Errorf("Match error: Expected unique values of %s in %s, elements %d and %d are both: %s",
+				field, path, first, idx, value.Raw)
+		}
+
+		seen[key] = idx
+	}
+
+	return nil
+}
+
+// uniqueKey normalizes value so that equal values have the same key, e.g.
+// `1` and `1.0` or objects with their keys in another order.
+func uniqueKey(value gjson.Result) string {
+	normalized, err := json.Marshal(value.Value())
+	if err != nil {
+		return value.Raw
+	}
+
+	return string(normalized)
+}
+
+func fieldValues(json []byte, path, field string) ([]gjson.Result, error) {
+	elements, err := readArray(json, path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]gjson.Result, len(elements))
+
+	for idx, element := range elements {
+		value, err := read([]byte(element.Raw), field)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Match error: Element %d at %s", idx, path)
+		}
+
+		values[idx] = value
+	}
+
+	return values, nil
+}
+
+// comparator picks how values are ordered, all of them have to be numbers,
+// timestamps or strings.
+func comparator(values []gjson.Result) (func(a, b gjson.Result) int, error) {
+	numbers, timestamps, strs := true, true, true
+
+	for _, value := range values {
+		numbers = numbers && value.Type == gjson.Number
+		strs = strs && value.Type == gjson.String
+
+		_, err := parseTime(value)
+		timestamps = timestamps && err == nil
+	}
+
+	switch {
+	case numbers:
+		return func(a, b gjson.Result) int {
+			switch {
+			case a.Float() < b.Float():
+				return -1
+			case a.Float() > b.Float():
+				return 1
+			}
+
+			return 0
+		}, nil
+	case timestamps:
+		return func(a, b gjson.Result) int {
+			ta, _ := parseTime(a)
+			tb, _ := parseTime(b)
+
+			return ta.Compare(tb)
+		}, nil
+	case strs:
+		return func(a, b gjson.Result) int {
+			return strings.Compare(a.String(), b.String())
+		}, nil
+	}
+
+	return nil, errors.New("Match error: Expected all values to be numbers, timestamps or strings")
+}
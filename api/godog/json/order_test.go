@@ -0,0 +1,48 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const orderJSON = `{
+	"data": [
+		{"id": "c", "weight": 1, "createdAt": "2024-01-01T05:00:00+05:00"},
+		{"id": "b", "weight": 2, "createdAt": "2024-01-01T01:00:00Z"},
+		{"id": "a", "weight": 2, "createdAt": "2024-01-02T00:00:00Z"}
+	],
+	"tags": ["a", "b", "b"]
+}`
+
+func TestIsSorted(t *testing.T) {
+	json := []byte(orderJSON)
+
+	assert.NoError(t, IsSorted(json, ".data", ".weight", Ascending))
+	assert.NoError(t, IsSorted(json, ".data", ".id", Descending))
+	assert.NoError(t, IsSorted(json, ".data", ".createdAt", Ascending))
+	assert.NoError(t, IsSorted(json, ".tags", ".", Ascending))
+	assert.NoError(t, IsSorted([]byte(`[]`), ".", ".", Ascending))
+
+	assert.EqualError(t, IsSorted(json, ".data", ".weight", Descending),
+		"Match error: Expected .data to be sorted by .weight in descending order, element 0: 1, element 1: 2")
+	assert.EqualError(t, IsSorted(json, ".data", ".createdAt", Descending),
+		`Match error: Expected .data to be sorted by .createdAt in descending order, element 0: "2024-01-01T05:00:00+05:00", element 1: "2024-01-01T01:00:00Z"`)
+	assert.Error(t, IsSorted(json, ".data", ".missing", Ascending))
+	assert.Error(t, IsSorted([]byte(`[1, "a"]`), ".", ".", Ascending))
+	assert.Error(t, IsSorted(json, ".data", ".id", "sideways"))
+}
+
+func TestIsUnique(t *testing.T) {
+	json := []byte(orderJSON)
+
+	assert.NoError(t, IsUnique(json, ".data", ".id"))
+	assert.EqualError(t, IsUnique(json, ".data", ".weight"),
+		"Match error: Expected unique values of .weight in .data, elements 1 and 2 are both: 2")
+	assert.Error(t, IsUnique(json, ".tags", "."))
+
+	assert.EqualError(t, IsUnique([]byte(`{"data": [1, 2, 1.0]}`), ".data", "."),
+		"Match error: Expected unique values of . in .data, elements 0 and 2 are both: 1.0")
+	assert.Error(t, IsUnique([]byte(`{"data": [{"a": 1, "b": 2}, {"b": 2, "a": 1}]}`), ".data", "."))
+	assert.NoError(t, IsUnique([]byte(`{"data": ["1", 1]}`), ".data", "."))
+}
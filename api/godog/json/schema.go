@@ -0,0 +1,106 @@
+package json
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const inlineSchemaURL = "inline-schema.json"
+
+var arrayIndexRegexp = regexp.MustCompile(`^\d+$`)
+
+// MatchSchema validates json against the JSON Schema in schema. Schemas
+// without a $schema keyword are treated as draft 2020-12.
+func MatchSchema(json []byte, schema []byte) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return errors.Wrap(err, "Schema error: failed to parse schema")
+	}
+
+	compiler := newSchemaCompiler()
+	if err = compiler.AddResource(inlineSchemaURL, doc); err != nil {
+		return errors.Wrap(err, "Schema error: failed to add schema")
+	}
+
+	return matchCompiledSchema(json, compiler, inlineSchemaURL)
+}
+
+// MatchSchemaFile validates json against the JSON Schema in filename,
+// relative $ref are resolved from the directory of filename.
+func MatchSchemaFile(json []byte, filename string) error {
+	return matchCompiledSchema(json, newSchemaCompiler(), filename)
+}
+
+func newSchemaCompiler() *jsonschema.Compiler {
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+
+	return compiler
+}
+
+func matchCompiledSchema(json []byte, compiler *jsonschema.Compiler, url string) error {
+	schema, err := compiler.Compile(url)
+	if err != nil {
+		return errors.Wrap(err, "Schema error: failed to compile schema")
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(json))
+	if err != nil {
+		return errors.Wrapf(err, "Schema error: failed to parse JSON: %s", string(json))
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return errors.Wrap(err, "Schema error")
+	}
+
+	violations := schemaViolations(validationErr)
+	sort.Strings(violations)
+
+	return errors.Errorf("Schema error: %d violation(s):\n\t%s\nJSON: %s", len(violations), strings.Join(violations, "\n\t"), string(json))
+}
+
+func schemaViolations(err *jsonschema.ValidationError) []string {
+	if len(err.Causes) == 0 {
+		return []string{fmt.Sprintf("%s: %s", legacyPath(err.InstanceLocation), err.BasicOutput().Error)}
+	}
+
+	var violations []string
+	for _, cause := range err.Causes {
+		violations = append(violations, schemaViolations(cause)...)
+	}
+
+	return violations
+}
+
+// legacyPath formats a JSON pointer as the paths used by the matcher, e.g.
+// `.data[0].id`.
+func legacyPath(tokens []string) string {
+	if len(tokens) == 0 {
+		return "."
+	}
+
+	var path strings.Builder
+
+	for _, token := range tokens {
+		if arrayIndexRegexp.MatchString(token) {
+			path.WriteString("[" + token + "]")
+		} else {
+			path.WriteString("." + token)
+		}
+	}
+
+	return path.String()
+}
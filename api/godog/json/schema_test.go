@@ -0,0 +1,53 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const nodeSchema = `{
+	"type": "object",
+	"required": ["id", "label"],
+	"properties": {
+		"id": {"type": "string", "format": "uuid"},
+		"label": {"type": "string"},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"createdAt": {"type": "string", "format": "date-time"}
+	},
+	"additionalProperties": false
+}`
+
+func TestMatchSchema(t *testing.T) {
+	json := []byte(`{"id": "2f1b3b7e-5b1f-4c2e-9f8b-0f4a0a1f5c3d", "label": "Pump 1", "tags": ["a"], "createdAt": "2024-01-02T03:04:05Z"}`)
+	require.Nil(t, MatchSchema(json, []byte(nodeSchema)))
+}
+
+func TestMatchSchemaViolations(t *testing.T) {
+	json := []byte(`{"id": "not-a-uuid", "tags": ["a", 1], "extra": true}`)
+
+	err := MatchSchema(json, []byte(nodeSchema))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "4 violation(s)")
+	require.Contains(t, err.Error(), ".: missing property 'label'")
+	require.Contains(t, err.Error(), ".id: ")
+	require.Contains(t, err.Error(), ".tags[1]: ")
+	require.Contains(t, err.Error(), "'extra'")
+}
+
+func TestMatchSchemaInvalid(t *testing.T) {
+	require.NotNil(t, MatchSchema([]byte(`{}`), []byte(`{"type": 1}`)))
+	require.NotNil(t, MatchSchema([]byte(`{}`), []byte(`{`)))
+	require.NotNil(t, MatchSchema([]byte(`{`), []byte(`{}`)))
+}
+
+func TestMatchSchemaFile(t *testing.T) {
+	require.Nil(t, MatchSchemaFile([]byte(`{"data": [{"id": "2f1b3b7e-5b1f-4c2e-9f8b-0f4a0a1f5c3d", "label": "Pump 1"}]}`), "testdata/node.schema.json"))
+
+	err := MatchSchemaFile([]byte(`{"data": [{"id": "2f1b3b7e-5b1f-4c2e-9f8b-0f4a0a1f5c3d", "label": ""}, {}]}`), "testdata/node.schema.json")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), ".data[0].label: ")
+	require.Contains(t, err.Error(), ".data[1]: missing properties 'id', 'label'")
+
+	require.NotNil(t, MatchSchemaFile([]byte(`{}`), "testdata/missing.schema.json"))
+}
@@ -0,0 +1,171 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// EnvUpdateSnapshots makes MatchSnapshot write snapshots instead of
+// comparing with them when set to true.
+const EnvUpdateSnapshots = "UPDATE_SNAPSHOTS"
+
+const redactedValue = "<redacted>"
+
+type snapshotConfig struct {
+	redacted         [][]string
+	redactUUIDs      bool
+	redactTimestamps bool
+	update           bool
+}
+
+type SnapshotOption func(*snapshotConfig)
+
+// RedactPaths replaces the values at paths with "<redacted>", `*` matches
+// any key or index, e.g. `.data[*].traceId`.
+func RedactPaths(paths ...string) SnapshotOption {
+	return func(c *snapshotConfig) {
+		for _, path := range paths {
+			c.redacted = append(c.redacted, pathSegments(path))
+		}
+	}
+}
+
+// RedactUUIDs replaces every UUID string with "<uuid>".
+func RedactUUIDs() SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.redactUUIDs = true
+	}
+}
+
+// RedactTimestamps replaces every RFC 3339 timestamp string with "<timestamp>".
+func RedactTimestamps() SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.redactTimestamps = true
+	}
+}
+
+// UpdateSnapshot overrides the UPDATE_SNAPSHOTS environment variable.
+func UpdateSnapshot(update bool) SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.update = update
+	}
+}
+
+func newSnapshotConfig(opts []SnapshotOption) snapshotConfig {
+	update, _ := strconv.ParseBool(os.Getenv(EnvUpdateSnapshots))
+
+	config := snapshotConfig{
+		update: update,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config
+}
+
+// Snapshot returns json indented, with sorted keys and volatile values redacted.
+func Snapshot(json []byte, opts ...SnapshotOption) ([]byte, error) {
+	return newSnapshotConfig(opts).normalize(json)
+}
+
+// MatchSnapshot compares json with the snapshot stored in filename. The
+// snapshot is only written when snapshots are updated, a missing snapshot is
+// an error so that suites can't pass without one, e.g. in CI. Snapshots are
+// compared with Equal, so placeholders such as "<any>" can be added to them
+// by hand.
+func MatchSnapshot(json []byte, filename string, opts ...SnapshotOption) error {
+	config := newSnapshotConfig(opts)
+
+	actual, err := config.normalize(json)
+	if err != nil {
+		return err
+	}
+
+	expected, err := os.ReadFile(filename)
+
+	switch {
+	case config.update && (err == nil || os.IsNotExist(err)):
+		return writeSnapshot(filename, actual)
+	case os.IsNotExist(err):
+		return errors.Errorf("Snapshot error: %s doesn't exist, set %s=true to create it", filename, EnvUpdateSnapshots)
+	case err != nil:
+		return errors.Wrapf(err, "Snapshot error: failed to read snapshot: %s", filename)
+	}
+
+	if err = Equal(actual, expected); err != nil {
+		return errors.Wrapf(err, "Snapshot error: JSON doesn't match %s, set %s=true to update it", filename, EnvUpdateSnapshots)
+	}
+
+	return nil
+}
+
+func writeSnapshot(filename string, snapshot []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return errors.Wrapf(err, "Snapshot error: failed to create snapshot directory for: %s", filename)
+	}
+
+	if err := os.WriteFile(filename, snapshot, 0600); err != nil {
+		return errors.Wrapf(err, "Snapshot error: failed to write snapshot: %s", filename)
+	}
+
+	return nil
+}
+
+func (c snapshotConfig) normalize(data []byte) ([]byte, error) {
+	value, err := decode(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Snapshot error: failed to parse JSON: %s", string(data))
+	}
+
+	var normalized bytes.Buffer
+
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(c.redact(nil, value)); err != nil {
+		return nil, errors.Wrap(err, "Snapshot error: failed to encode JSON")
+	}
+
+	return normalized.Bytes(), nil
+}
+
+func (c snapshotConfig) redact(path []string, value interface{}) interface{} {
+	if matchesAny(c.redacted, path) {
+		return redactedValue
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = c.redact(appendPath(path, key), child)
+		}
+	case []interface{}:
+		for idx, child := range value {
+			value[idx] = c.redact(appendPath(path, strconv.Itoa(idx)), child)
+		}
+	case string:
+		if c.redactUUIDs && len(value) == 36 {
+			if _, err := uuid.Parse(value); err == nil {
+				return "<uuid>"
+			}
+		}
+
+		if c.redactTimestamps {
+			if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				return "<timestamp>"
+			}
+		}
+	}
+
+	return value
+}
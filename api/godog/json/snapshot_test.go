@@ -0,0 +1,63 @@
+package json
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const snapshotJSON = `{"data":{"traceId":"abc","name":"Pump <1>","id":"0b1e3f6a-0c5e-4f5e-8d5b-3f3c3a1c9b1e","createdAt":"2024-01-02T03:04:05Z","count":1}}`
+
+func TestSnapshot(t *testing.T) {
+	snapshot, err := Snapshot([]byte(snapshotJSON), RedactPaths(".data.traceId"), RedactUUIDs(), RedactTimestamps())
+	require.NoError(t, err)
+
+	expected := `{
+  "data": {
+    "count": 1,
+    "createdAt": "<timestamp>",
+    "id": "<uuid>",
+    "name": "Pump <1>",
+    "traceId": "<redacted>"
+  }
+}
+`
+	require.Equal(t, expected, string(snapshot))
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	t.Setenv(EnvUpdateSnapshots, "")
+
+	filename := filepath.Join(t.TempDir(), "nodes", "get_node.json")
+	opts := []SnapshotOption{RedactUUIDs(), RedactTimestamps()}
+
+	err := MatchSnapshot([]byte(snapshotJSON), filename, opts...)
+	require.ErrorContains(t, err, "Snapshot error: "+filename+" doesn't exist, set UPDATE_SNAPSHOTS=true to create it")
+	require.NoFileExists(t, filename)
+
+	require.NoError(t, MatchSnapshot([]byte(snapshotJSON), filename, append(opts, UpdateSnapshot(true))...))
+	require.FileExists(t, filename)
+	require.NoError(t, MatchSnapshot([]byte(snapshotJSON), filename, opts...))
+
+	changed := `{"data":{"traceId":"abc","name":"Pump 2","id":"4c6a3e64-6b0e-4a4f-9f7a-2b1d3c4e5f60","createdAt":"2024-02-02T03:04:05Z","count":1}}`
+
+	err = MatchSnapshot([]byte(changed), filename, opts...)
+	require.ErrorContains(t, err, "Snapshot error: JSON doesn't match "+filename+", set UPDATE_SNAPSHOTS=true to update it")
+	require.ErrorContains(t, err, "1 difference(s)")
+	require.ErrorContains(t, err, ".data.name")
+
+	t.Setenv(EnvUpdateSnapshots, "true")
+	require.NoError(t, MatchSnapshot([]byte(changed), filename, opts...))
+
+	t.Setenv(EnvUpdateSnapshots, "")
+	require.NoError(t, MatchSnapshot([]byte(changed), filename, opts...))
+}
+
+func TestMatchSnapshot_Placeholders(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"data":{"traceId":"<any>","count":"<number>"}}`), 0600))
+
+	require.NoError(t, MatchSnapshot([]byte(`{"data":{"traceId":"xyz","count":3}}`), filename, UpdateSnapshot(false)))
+}
@@ -0,0 +1,153 @@
+package godog
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+)
+
+var (
+	docStringType = reflect.TypeOf(&godog.DocString{})
+	tableType     = reflect.TypeOf(&godog.Table{})
+)
+
+type registeredStep struct {
+	expr *regexp.Regexp
+	fn   reflect.Value
+}
+
+// stepMatcher finds and calls the registered step matching a step text, it
+// lets step modifiers such as `within` run other steps. Steps are matched
+// without the prefix of WithStepPrefix since modifiers are prefixed already.
+type stepMatcher struct {
+	steps []registeredStep
+	// current is the step being run, its docstring or table is passed on to
+	// the matched step
+	current *godog.Step
+}
+
+func newStepMatcher(definitions []stepDefinition, options stepOptions) (*stepMatcher, error) {
+	m := &stepMatcher{}
+
+	for _, definition := range definitions {
+		expression := definition.expr
+		if expr, exists := options.expressions[definition.name]; exists {
+			expression = expr
+		}
+
+		expr, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expression of step %s", definition.name)
+		}
+
+		m.steps = append(m.steps, registeredStep{expr: expr, fn: reflect.ValueOf(definition.fn)})
+	}
+
+	return m, nil
+}
+
+// register keeps track of the step being run.
+func (m *stepMatcher) register(sc *godog.ScenarioContext) {
+	sc.StepContext().Before(func(ctx context.Context, step *godog.Step) (context.Context, error) {
+		m.current = step
+		return ctx, nil
+	})
+}
+
+// find returns a call of the step matching step with the docstring or table
+// of the current step.
+func (m *stepMatcher) find(step string) (func() error, error) {
+	var (
+		doc   *godog.DocString
+		table *godog.Table
+	)
+
+	if m.current != nil && m.current.Argument != nil {
+		doc, table = m.current.Argument.DocString, m.current.Argument.DataTable
+	}
+
+	for _, s := range m.steps {
+		match := s.expr.FindStringSubmatch(step)
+		if match == nil {
+			continue
+		}
+
+		args, err := stepArguments(s.fn.Type(), match[1:], doc, table)
+		if err != nil {
+			return nil, errors.Wrapf(err, "step '%s'", step)
+		}
+
+		fn := s.fn
+
+		return func() error {
+			if err, _ := fn.Call(args)[0].Interface().(error); err != nil {
+				return err
+			}
+
+			return nil
+		}, nil
+	}
+
+	return nil, errors.Errorf("no step matches '%s'", step)
+}
+
+// stepArguments converts the captured values to the parameters of a step
+// function the way godog does for the types used by the step library.
+func stepArguments(fnType reflect.Type, values []string, doc *godog.DocString, table *godog.Table) ([]reflect.Value, error) {
+	args := make([]reflect.Value, 0, fnType.NumIn())
+
+	for idx := 0; idx < fnType.NumIn(); idx++ {
+		param := fnType.In(idx)
+
+		if param == docStringType {
+			if doc == nil {
+				return nil, errors.New("expected a docstring")
+			}
+
+			args = append(args, reflect.ValueOf(doc))
+
+			continue
+		}
+
+		if param == tableType {
+			if table == nil {
+				return nil, errors.New("expected a table")
+			}
+
+			args = append(args, reflect.ValueOf(table))
+
+			continue
+		}
+
+		if idx >= len(values) {
+			return nil, errors.Errorf("expected %d arguments got %d", fnType.NumIn(), len(values))
+		}
+
+		switch param.Kind() {
+		case reflect.String:
+			args = append(args, reflect.ValueOf(values[idx]).Convert(param))
+		case reflect.Int:
+			value, err := strconv.Atoi(values[idx])
+			if err != nil {
+				return nil, errors.Wrapf(err, "argument %d", idx)
+			}
+
+			args = append(args, reflect.ValueOf(value))
+		case reflect.Float64:
+			value, err := strconv.ParseFloat(values[idx], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "argument %d", idx)
+			}
+
+			args = append(args, reflect.ValueOf(value))
+		default:
+			return nil, errors.Errorf("unsupported argument type %s", param)
+		}
+	}
+
+	return args, nil
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/pkg/errors"
+)
+
+var serverHostRegexp = regexp.MustCompile(`^[^:/]+://[^/]*`)
+
+// Contract validates requests and responses against an OpenAPI 3 document
+// and keeps track of which operations and status codes have been exercised.
+type Contract struct {
+	doc    *openapi3.T
+	router routers.Router
+
+	lock      sync.Mutex
+	exercised map[operation]map[int]int
+}
+
+type operation struct {
+	Method string
+	Path   string
+}
+
+// Load reads and validates an OpenAPI 3 document. Requests are matched on
+// the path of the declared servers only, so the suite can run against any host.
+func Load(filename string) (*Contract, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+
+	doc, err := loader.LoadFromFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load OpenAPI document: %s", filename)
+	}
+
+	if err = doc.Validate(loader.Context); err != nil {
+		return nil, errors.Wrapf(err, "invalid OpenAPI document: %s", filename)
+	}
+
+	return New(doc)
+}
+
+// New creates a Contract of doc, doc isn't changed.
+func New(doc *openapi3.T) (*Contract, error) {
+	// The router is created of a copy with only the paths of the servers
+	routed := *doc
+	routed.Servers = make(openapi3.Servers, 0, len(doc.Servers))
+
+	for _, server := range doc.Servers {
+		relative := *server
+		relative.URL = serverHostRegexp.ReplaceAllString(server.URL, "")
+		if relative.URL == "" {
+			relative.URL = "/"
+		}
+
+		routed.Servers = append(routed.Servers, &relative)
+	}
+
+	router, err := gorillamux.NewRouter(&routed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OpenAPI router")
+	}
+
+	return &Contract{
+		doc:       doc,
+		router:    router,
+		exercised: make(map[operation]map[int]int),
+	}, nil
+}
+
+// Validate validates an executed request and its response. Request errors
+// are only reported when the response is successful, as negative tests send
+// invalid requests on purpose and expect them to be rejected. Responses with
+// a status code the operation doesn't declare are reported as errors.
+func (c *Contract) Validate(ctx context.Context, req *http.Request, requestBody []byte, resp *http.Response, responseBody []byte) error {
+	route, pathParams, err := c.router.FindRoute(req)
+	if err != nil {
+		return errors.Wrapf(err, "OpenAPI error: %s %s", req.Method, req.URL.Path)
+	}
+
+	c.record(route, resp.StatusCode)
+
+	options := &openapi3filter.Options{
+		IncludeResponseStatus: true,
+		MultiError:            true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+
+	// Validation reads the body, so the request is cloned with a fresh one
+	validationRequest := req.Clone(ctx)
+	validationRequest.Body = io.NopCloser(bytes.NewReader(requestBody))
+
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    validationRequest,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    options,
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		if err = openapi3filter.ValidateRequest(ctx, requestInput); err != nil {
+			return errors.Wrapf(err, "OpenAPI error: request %s %s was accepted with %d but doesn't match the contract",
+				req.Method, route.Path, resp.StatusCode)
+		}
+	}
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Options:                options,
+	}
+	responseInput.SetBodyBytes(responseBody)
+
+	if err = openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+		return errors.Wrapf(err, "OpenAPI error: response %d of %s %s doesn't match the contract",
+			resp.StatusCode, req.Method, route.Path)
+	}
+
+	return nil
+}
+
+func (c *Contract) record(route *routers.Route, statusCode int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	op := operation{Method: route.Method, Path: route.Path}
+	if c.exercised[op] == nil {
+		c.exercised[op] = make(map[int]int)
+	}
+
+	c.exercised[op][statusCode]++
+}
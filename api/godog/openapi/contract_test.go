@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func newNodeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/v1/nodes/1":
+			_, _ = io.WriteString(rw, `{"id":"1","name":"node"}`)
+		case "/v1/nodes/2":
+			_, _ = io.WriteString(rw, `{"id":"2"}`)
+		case "/v1/nodes/3":
+			rw.WriteHeader(http.StatusInternalServerError)
+		case "/v1/nodes":
+			if req.Header.Get("X-Reject") != "" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(rw, `{"id":"1"}`)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func do(t *testing.T, contract *Contract, req *http.Request, payload []byte) error {
+	t.Helper()
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return contract.Validate(context.Background(), req, payload, resp, body)
+}
+
+func get(t *testing.T, contract *Contract, url string) error {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	return do(t, contract, req, nil)
+}
+
+func post(t *testing.T, contract *Contract, url, payload string, header http.Header) error {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(payload))
+	require.NoError(t, err)
+
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+
+	return do(t, contract, req, []byte(payload))
+}
+
+func TestContract_Validate(t *testing.T) {
+	ts := newNodeServer()
+	defer ts.Close()
+
+	contract, err := Load("testdata/nodes.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, get(t, contract, ts.URL+"/v1/nodes/1"))
+	require.NoError(t, post(t, contract, ts.URL+"/v1/nodes", `{"name":"node"}`, http.Header{}))
+
+	err = get(t, contract, ts.URL+"/v1/nodes/2")
+	require.ErrorContains(t, err, "response 200 of GET /nodes/{id} doesn't match the contract")
+	require.ErrorContains(t, err, `property "name" is missing`)
+
+	err = get(t, contract, ts.URL+"/v1/nodes/3")
+	require.ErrorContains(t, err, "response 500 of GET /nodes/{id} doesn't match the contract")
+
+	err = post(t, contract, ts.URL+"/v1/nodes", `{"title":"node"}`, http.Header{})
+	require.ErrorContains(t, err, "request POST /nodes was accepted with 201 but doesn't match the contract")
+
+	err = get(t, contract, ts.URL+"/v1/unknown")
+	require.ErrorContains(t, err, "OpenAPI error: GET /v1/unknown")
+}
+
+func TestContract_Validate_RejectedRequest(t *testing.T) {
+	ts := newNodeServer()
+	defer ts.Close()
+
+	contract, err := Load("testdata/nodes.yaml")
+	require.NoError(t, err)
+
+	err = post(t, contract, ts.URL+"/v1/nodes", `{"title":"node"}`, http.Header{"X-Reject": {"true"}})
+	require.NoError(t, err)
+}
+
+func TestContract_Coverage(t *testing.T) {
+	ts := newNodeServer()
+	defer ts.Close()
+
+	contract, err := Load("testdata/nodes.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, get(t, contract, ts.URL+"/v1/nodes/1"))
+	require.NoError(t, post(t, contract, ts.URL+"/v1/nodes", `{}`, http.Header{"X-Reject": {"true"}}))
+
+	coverage := contract.Coverage()
+	require.Len(t, coverage.Operations, 2)
+	require.Equal(t, map[int]int{http.StatusOK: 1}, coverage.Operations[1].Exercised)
+	require.InDelta(t, 0.5, coverage.Ratio(), 0.001)
+
+	expected := strings.Join([]string{
+		"OpenAPI coverage: 50%",
+		"POST /nodes: 201 [ ], 4XX [x]",
+		"GET /nodes/{id}: 200 [x], 404 [ ]",
+		"",
+	}, "\n")
+	require.Equal(t, expected, coverage.String())
+}
+
+func TestNew_KeepsDocument(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromFile("testdata/nodes.yaml")
+	require.NoError(t, err)
+
+	_, err = New(doc)
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/v1", doc.Servers[0].URL)
+}
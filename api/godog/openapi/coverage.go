@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Coverage lists every operation of the contract with the status codes it
+// declares and the ones the suite has exercised.
+type Coverage struct {
+	Operations []OperationCoverage
+}
+
+type OperationCoverage struct {
+	Method string
+	Path   string
+
+	// Declared status codes, "default" and ranges such as "4XX" included
+	Declared []string
+	// Exercised status codes and how many responses had them
+	Exercised map[int]int
+}
+
+func (o OperationCoverage) covered(status string) bool {
+	for code := range o.Exercised {
+		if status == strconv.Itoa(code) || (len(status) == 3 && strings.HasSuffix(status, "XX") && status[0] == strconv.Itoa(code)[0]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Coverage returns the current coverage of the contract.
+func (c *Contract) Coverage() Coverage {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var coverage Coverage
+
+	for path, item := range c.doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			declared := make([]string, 0, op.Responses.Len())
+			for status := range op.Responses.Map() {
+				declared = append(declared, status)
+			}
+
+			sort.Strings(declared)
+
+			exercised := make(map[int]int)
+			for code, count := range c.exercised[operation{Method: method, Path: path}] {
+				exercised[code] = count
+			}
+
+			coverage.Operations = append(coverage.Operations, OperationCoverage{
+				Method:    method,
+				Path:      path,
+				Declared:  declared,
+				Exercised: exercised,
+			})
+		}
+	}
+
+	sort.Slice(coverage.Operations, func(i, j int) bool {
+		a, b := coverage.Operations[i], coverage.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		return a.Method < b.Method
+	})
+
+	return coverage
+}
+
+// Ratio returns the share of declared status codes, "default" excluded,
+// that have been exercised.
+func (c Coverage) Ratio() float64 {
+	declared, covered := 0, 0
+
+	for _, op := range c.Operations {
+		for _, status := range op.Declared {
+			if status == "default" {
+				continue
+			}
+
+			declared++
+
+			if op.covered(status) {
+				covered++
+			}
+		}
+	}
+
+	if declared == 0 {
+		return 0
+	}
+
+	return float64(covered) / float64(declared)
+}
+
+// String formats the coverage as one line per operation, e.g.
+// `GET /nodes/{id}: 200 [x], 404 [ ]`.
+func (c Coverage) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "OpenAPI coverage: %.0f%%\n", 100*c.Ratio())
+
+	for _, op := range c.Operations {
+		statuses := make([]string, 0, len(op.Declared))
+
+		for _, status := range op.Declared {
+			mark := "[ ]"
+			if op.covered(status) {
+				mark = "[x]"
+			}
+
+			statuses = append(statuses, status+" "+mark)
+		}
+
+		fmt.Fprintf(&sb, "%s %s: %s\n", op.Method, op.Path, strings.Join(statuses, ", "))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,135 @@
+package godog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// MaxPages limits how many pages the pagination helpers request before
+// giving up, to protect against endpoints that never stop linking.
+const MaxPages = 100
+
+// ExecuteTheRequestFollowingNextLinks executes the request and follows the
+// link at nextLinkPath of every response, e.g. `.links.next`, until it is
+// missing or empty. The elements of the arrays at itemsPath are collected
+// and the response body is replaced with `{"data": [...]}` holding all of
+// them, so the usual assertions run over the complete result set. The rest
+// of the response, e.g. Raw with its status code and headers, is the one of
+// the last page. The request is left as it was before the first page.
+func (api *BaseFeature) ExecuteTheRequestFollowingNextLinks(itemsPath, nextLinkPath string) error {
+	return api.collectPages(itemsPath, nextLinkPath, func(next string) error {
+		current, err := url.Parse(api.Request.Url)
+		if err != nil {
+			return errors.Wrapf(err, "invalid request url: %s", api.Request.Url)
+		}
+
+		link, err := url.Parse(next)
+		if err != nil {
+			return errors.Wrapf(err, "invalid next link: %s", next)
+		}
+
+		// The next link carries the complete query of the next page
+		api.Request.Url = current.ResolveReference(link).String()
+		api.Request.Query = url.Values{}
+
+		return nil
+	})
+}
+
+// ExecuteTheRequestFollowingCursor works like
+// ExecuteTheRequestFollowingNextLinks, but sends the cursor found at
+// cursorPath as the query parameter of the next request.
+func (api *BaseFeature) ExecuteTheRequestFollowingCursor(itemsPath, cursorPath, parameter string) error {
+	return api.collectPages(itemsPath, cursorPath, func(cursor string) error {
+		current, err := url.Parse(api.Request.Url)
+		if err != nil {
+			return errors.Wrapf(err, "invalid request url: %s", api.Request.Url)
+		}
+
+		// A cursor in the url would be sent next to the new one
+		if query := current.Query(); query.Has(parameter) {
+			query.Del(parameter)
+			current.RawQuery = query.Encode()
+			api.Request.Url = current.String()
+		}
+
+		if api.Request.Query == nil {
+			api.Request.Query = url.Values{}
+		}
+
+		api.Request.Query.Set(parameter, cursor)
+
+		return nil
+	})
+}
+
+func (api *BaseFeature) collectPages(itemsPath, nextPath string, advance func(next string) error) error {
+	request := api.Request.clone()
+	defer func() {
+		api.Request = request
+	}()
+
+	items := []interface{}{}
+
+	for page := 1; ; page++ {
+		if page > MaxPages {
+			return errors.Errorf("pagination didn't end within %d pages", MaxPages)
+		}
+
+		if err := api.ExecuteTheRequest(); err != nil {
+			return err
+		}
+
+		if code := api.lastStatusCode(); code >= http.StatusBadRequest {
+			return errors.Errorf("page %d failed with response code %d: %s", page, code, api.Response.Body)
+		}
+
+		value, err := json_matcher.ReadValue(api.Response.Body, itemsPath)
+		if err != nil {
+			return errors.Wrapf(err, "page %d", page)
+		}
+
+		pageItems, ok := value.([]interface{})
+		if !ok {
+			return errors.Errorf("page %d: expected an array at %s got: %v", page, itemsPath, value)
+		}
+
+		items = append(items, pageItems...)
+
+		next, err := json_matcher.ReadValue(api.Response.Body, nextPath)
+		if err != nil || next == nil || next == "" {
+			break
+		}
+
+		nextValue, err := formatVariable(next)
+		if err != nil {
+			return err
+		}
+
+		if err = advance(nextValue); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"data": items})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal failed")
+	}
+
+	api.Response.Body = body
+
+	return nil
+}
+
+func (api *BaseFeature) AssertResponseSorted(arrayPath, field, order string) error {
+	return json_matcher.IsSorted(api.Response.Body, arrayPath, field, json_matcher.Order(order))
+}
+
+func (api *BaseFeature) AssertResponseUnique(arrayPath, field string) error {
+	return json_matcher.IsUnique(api.Response.Body, arrayPath, field)
+}
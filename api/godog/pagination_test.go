@@ -0,0 +1,122 @@
+package godog_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+// newPagingServer serves the ids 1 to 5 in pages of two, linked both with a
+// cursor and with a next link.
+func newPagingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := 1
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		items, next, cursor := "", `""`, `null`
+
+		for id := start; id < start+2 && id <= 5; id++ {
+			if items != "" {
+				items += ","
+			}
+
+			items += fmt.Sprintf(`{"id": %d}`, id)
+		}
+
+		if start+2 <= 5 {
+			next = fmt.Sprintf(`"/nodes?limit=2&cursor=%d"`, start+2)
+			cursor = fmt.Sprintf(`"%d"`, start+2)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data": [%s], "links": {"next": %s}, "nextCursor": %s}`, items, next, cursor)
+	}))
+}
+
+const paginationFeature = `
+Feature: pagination
+
+  Scenario: follow next links
+    Given I create a "GET" request to "/nodes"
+    And I set the request query parameter "limit" to "2"
+    When I execute the request collecting ".data" from every page linked by ".links.next"
+    Then the response data should contain 5 items
+    And the elements of ".data" should be sorted by ".id" ascending
+    And every element of ".data" should have a unique ".id"
+
+  Scenario: follow cursors
+    Given I create a "GET" request to "/nodes"
+    And I set the request query parameter "limit" to "2"
+    When I execute the request collecting ".data" from every page using the cursor ".nextCursor" as the query parameter "cursor"
+    Then the response data should contain 5 items
+    And the response value ".data[4].id" should equal "5"
+`
+
+func TestRegisterSteps_Pagination(t *testing.T) {
+	s := newPagingServer(t)
+	defer s.Close()
+
+	status := runFeature(t, paginationFeature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestBaseFeature_PaginationFailingPage(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		fmt.Fprintln(w, `{"data": [1], "next": "?page=2"}`)
+	}))
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePathRequest(http.MethodGet, "/nodes"))
+
+	err := api.ExecuteTheRequestFollowingNextLinks(".data", ".next")
+	require.EqualError(t, err, "page 2 failed with response code 500: ")
+}
+
+func TestBaseFeature_PaginationRestoresRequest(t *testing.T) {
+	s := newPagingServer(t)
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePathRequest(http.MethodGet, "/nodes?cursor=3"))
+	require.NoError(t, api.SetRequestQueryParameterTo("limit", "2"))
+
+	require.NoError(t, api.ExecuteTheRequestFollowingCursor(".data", ".nextCursor", "cursor"))
+	assert.JSONEq(t, `{"data": [{"id": 3}, {"id": 4}, {"id": 5}]}`, string(api.Response.Body))
+	assert.Equal(t, s.URL+"/nodes?cursor=3", api.Request.Url)
+	assert.Equal(t, "limit=2", api.Request.Query.Encode())
+
+	require.NoError(t, api.ExecuteTheRequestFollowingNextLinks(".data", ".links.next"))
+	assert.JSONEq(t, `{"data": [{"id": 3}, {"id": 4}, {"id": 5}]}`, string(api.Response.Body))
+	assert.Equal(t, s.URL+"/nodes?cursor=3", api.Request.Url)
+	assert.Equal(t, "limit=2", api.Request.Query.Encode())
+}
@@ -0,0 +1,197 @@
+package godog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	"github.com/SKF/go-utility/v2/log"
+
+	"github.com/SKF/go-tests-utility/api/godog/internal/scenario"
+)
+
+const redacted = "<redacted>"
+
+// DefaultRedactedHeaders are the headers redacted by RedactHeaders when no
+// names are given, by the reporter and by the request dump.
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// requestDump is where and how RegisterRequestDump writes requests.
+type requestDump struct {
+	dir  string
+	opts []RenderOption
+}
+
+type renderConfig struct {
+	redactedHeaders []string
+}
+
+type RenderOption func(*renderConfig)
+
+// RedactHeaders replaces the values of the headers names by `<redacted>`,
+// DefaultRedactedHeaders are used when no names are given.
+func RedactHeaders(names ...string) RenderOption {
+	if len(names) == 0 {
+		names = DefaultRedactedHeaders
+	}
+
+	return func(c *renderConfig) {
+		c.redactedHeaders = append(c.redactedHeaders, names...)
+	}
+}
+
+func newRenderConfig(opts []RenderOption) renderConfig {
+	var c renderConfig
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Curl renders the request as a curl command, with the body sent by its
+// last execution.
+func (r *Request) Curl(opts ...RenderOption) string {
+	c := newRenderConfig(opts)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "curl -X %s %s", r.Method, shellQuote(r.displayURL()))
+
+	for _, line := range headerLines(r.header(), c.redactedHeaders) {
+		fmt.Fprintf(&b, " \\\n  -H %s", shellQuote(line))
+	}
+
+	if len(r.payload) > 0 {
+		fmt.Fprintf(&b, " \\\n  --data-raw %s", shellQuote(string(r.payload)))
+	}
+
+	return b.String() + "\n"
+}
+
+// HTTPFile renders the request as a request of a `.http` file, as used by
+// the REST clients of IntelliJ and VS Code, with the body sent by its last
+// execution.
+func (r *Request) HTTPFile(opts ...RenderOption) string {
+	c := newRenderConfig(opts)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s\n", r.Method, r.displayURL())
+
+	for _, line := range headerLines(r.header(), c.redactedHeaders) {
+		b.WriteString(line + "\n")
+	}
+
+	if len(r.payload) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", r.payload)
+	}
+
+	return b.String()
+}
+
+// RegisterRequestDump makes api write the requests it executes to dir, as
+// `<feature>/<scenario>.http` and `<feature>/<scenario>.sh`, named like
+// snapshots for the rows of a scenario outline. The files are
+// rewritten when the scenario runs again. DefaultRedactedHeaders are always
+// redacted, RedactHeaders adds more.
+func RegisterRequestDump(sc *godog.ScenarioContext, api *BaseFeature, dir string, opts ...RenderOption) {
+	api.requestDump = &requestDump{
+		dir:  dir,
+		opts: append([]RenderOption{RedactHeaders()}, opts...),
+	}
+
+	sc.Before(func(ctx context.Context, scenario *godog.Scenario) (context.Context, error) {
+		api.SetScenario(scenario)
+		return ctx, nil
+	})
+}
+
+// dumpRequest appends the request to the dump files of the scenario, the
+// first request of a scenario truncates them.
+func (api *BaseFeature) dumpRequest() {
+	dump := api.requestDump
+	if dump == nil {
+		return
+	}
+
+	api.dumpedRequests++
+
+	base := filepath.Join(dump.dir, "requests")
+	if api.scenario != nil {
+		base = filepath.Join(dump.dir, snapshotName(scenario.Feature(api.scenario)), snapshotName(scenario.Name(api.scenario)))
+	}
+
+	title := fmt.Sprintf("#%d %s %s", api.dumpedRequests, api.Request.Method, api.Request.ExecutionTime.Format("15:04:05.000"))
+
+	files := map[string]string{
+		base + ".http": fmt.Sprintf("### %s\n%s\n", title, api.Request.HTTPFile(dump.opts...)),
+		base + ".sh":   fmt.Sprintf("# %s\n%s\n", title, api.Request.Curl(dump.opts...)),
+	}
+
+	for filename, content := range files {
+		if err := appendFile(filename, content, api.dumpedRequests == 1); err != nil {
+			log.Errorf("Failed to dump request: %s", err)
+		}
+	}
+}
+
+func appendFile(filename, content string, truncate bool) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0750); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %s", filename)
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if truncate {
+		flag |= os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(filename, flag, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", filename)
+	}
+
+	_, err = f.WriteString(content)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+
+	return err
+}
+
+// headerLines returns `Name: value` for every header value sorted by name,
+// values of redactedHeaders are replaced.
+func headerLines(header http.Header, redactedHeaders []string) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var lines []string
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			if containsFold(redactedHeaders, name) {
+				value = redacted
+			}
+
+			lines = append(lines, fmt.Sprintf("%s: %s", name, value))
+		}
+	}
+
+	return lines
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,90 @@
+package godog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+func TestRequestRendering(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	api := &api_godog.BaseFeature{}
+	api.SetBaseUrl(s.URL)
+
+	require.NoError(t, api.CreatePathRequest("POST", "/nodes"))
+	require.NoError(t, api.SetRequestHeaderParameterTo("Authorization", "Bearer secret"))
+	require.NoError(t, api.SetRequestQueryParameterTo("dryRun", "true"))
+	require.NoError(t, api.SetRequestBodyParameterTo("name", "Pump's"))
+	require.NoError(t, api.ExecuteTheRequest())
+
+	require.Equal(t, `curl -X POST '`+s.URL+`/nodes?dryRun=true' \
+  -H 'Authorization: Bearer secret' \
+  -H 'Content-Type: application/json' \
+  --data-raw '{"name":"Pump'\''s"}'
+`, api.Request.Curl())
+
+	require.Equal(t, `POST `+s.URL+`/nodes?dryRun=true
+Authorization: <redacted>
+Content-Type: application/json
+
+{"name":"Pump's"}
+`, api.Request.HTTPFile(api_godog.RedactHeaders()))
+
+	require.Contains(t, api.Request.Curl(api_godog.RedactHeaders("content-type")), "-H 'Content-Type: <redacted>'")
+}
+
+func TestRegisterRequestDump(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	dir := t.TempDir()
+
+	feature := `
+Feature: dumping
+
+  Scenario: create and get a node
+    Given I create a "POST" request to "/nodes"
+    And I set the request header "Authorization" to "Bearer secret"
+    And I set the request header "X-Test" to "abc"
+    And I set the request body parameter "name" to "Pump 1"
+    When I execute the request
+    And I create a "GET" request to "/nodes/1"
+    And I execute the request
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+		api_godog.RegisterRequestDump(sc, api, dir, api_godog.RedactHeaders("X-Test"))
+	})
+	require.Equal(t, 0, status)
+
+	httpFile, err := os.ReadFile(filepath.Join(dir, "steps", "create_and_get_a_node.http"))
+	require.NoError(t, err)
+	require.Regexp(t, `^### #1 POST \d\d:\d\d:\d\d\.\d{3}
+POST http://127\.0\.0\.1:\d+/nodes
+Authorization: <redacted>
+Content-Type: application/json
+X-Test: <redacted>
+
+\{"name":"Pump 1"\}
+
+### #2 GET \d\d:\d\d:\d\d\.\d{3}
+GET http://127\.0\.0\.1:\d+/nodes/1
+
+$`, string(httpFile))
+
+	curl, err := os.ReadFile(filepath.Join(dir, "steps", "create_and_get_a_node.sh"))
+	require.NoError(t, err)
+	require.Contains(t, string(curl), "\n# #2 GET ")
+	require.NotContains(t, string(curl), "secret")
+}
@@ -0,0 +1,140 @@
+package godog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/SKF/go-tests-utility/api/godog/internal/truncate"
+)
+
+// DefaultMaxReportBody is how many bytes of the response body are attached
+// by the reporter unless WithMaxReportBody is used.
+const DefaultMaxReportBody = 64 * 1024
+
+type reporter struct {
+	api             *BaseFeature
+	redactedHeaders []string
+	maxBody         int
+}
+
+type ReportOption func(*reporter)
+
+// WithRedactedHeaders redacts the values of the headers names in addition
+// to DefaultRedactedHeaders.
+func WithRedactedHeaders(names ...string) ReportOption {
+	return func(r *reporter) {
+		r.redactedHeaders = append(r.redactedHeaders, names...)
+	}
+}
+
+// WithMaxReportBody truncates the attached response body to size bytes, zero
+// attaches it in full.
+func WithMaxReportBody(size int) ReportOption {
+	return func(r *reporter) {
+		r.maxBody = size
+	}
+}
+
+// RegisterReporter attaches the last request as a curl command, the response
+// headers and the pretty-printed response body to a failing step, or a step
+// with a failing soft assertion, the attachments end up in the cucumber
+// formatter output. Steps asserting on a saved exchange, such as
+// `the response of <alias> ...`, get that exchange attached.
+func RegisterReporter(sc *godog.ScenarioContext, api *BaseFeature, opts ...ReportOption) {
+	r := &reporter{
+		api:             api,
+		redactedHeaders: append([]string{}, DefaultRedactedHeaders...),
+		maxBody:         DefaultMaxReportBody,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	sc.StepContext().Before(func(ctx context.Context, step *godog.Step) (context.Context, error) {
+		api.usedExchange = nil
+		return ctx, nil
+	})
+
+	sc.StepContext().After(func(ctx context.Context, step *godog.Step, status godog.StepResultStatus, err error) (context.Context, error) {
+		// Steps such as `the response of <alias> ...` assert on a saved exchange
+		exchange := Exchange{Request: api.Request, Response: api.Response}
+		if api.usedExchange != nil {
+			exchange = *api.usedExchange
+		}
+
+		failed := status == godog.StepFailed || api.soft.stepFailed
+		if !failed || exchange.Response.Raw == nil {
+			return ctx, nil
+		}
+
+		return godog.Attach(ctx, r.attachments(exchange)...), nil
+	})
+}
+
+func (r *reporter) attachments(exchange Exchange) []godog.Attachment {
+	resp := exchange.Response
+
+	body, mediaType := r.body(resp.Body, resp.Raw.Header.Get("Content-Type"))
+
+	return []godog.Attachment{
+		{
+			Body:      []byte(exchange.Request.Curl(RedactHeaders(r.redactedHeaders...))),
+			FileName:  "request.sh",
+			MediaType: "text/plain",
+		},
+		{
+			Body:      []byte(fmt.Sprintf("%s %s\n%s", resp.Raw.Proto, resp.Raw.Status, formatHeaders(resp.Raw.Header, r.redactedHeaders))),
+			FileName:  "response-headers.txt",
+			MediaType: "text/plain",
+		},
+		{
+			Body:      body,
+			FileName:  "response-body" + fileExtension(mediaType),
+			MediaType: mediaType,
+		},
+	}
+}
+
+// body pretty-prints JSON bodies and truncates them to maxBody.
+func (r *reporter) body(body []byte, contentType string) ([]byte, string) {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	if mediaType == "" {
+		mediaType = "text/plain"
+	}
+
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, body, "", "  ") == nil {
+		body = pretty.Bytes()
+	}
+
+	if r.maxBody > 0 && len(body) > r.maxBody {
+		return truncate.Body(body, r.maxBody), "text/plain"
+	}
+
+	return body, mediaType
+}
+
+func formatHeaders(header http.Header, redactedHeaders []string) string {
+	var b strings.Builder
+
+	for _, line := range headerLines(header, redactedHeaders) {
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
+
+func fileExtension(mediaType string) string {
+	if strings.HasSuffix(mediaType, "json") {
+		return ".json"
+	}
+
+	return ".txt"
+}
@@ -0,0 +1,161 @@
+package godog_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+func runReportedFeature(t *testing.T, feature string, opts ...api_godog.ReportOption) []godog.Attachment {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	var attachments []godog.Attachment
+
+	runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+		api_godog.RegisterReporter(sc, api, opts...)
+
+		sc.StepContext().After(func(ctx context.Context, _ *godog.Step, _ godog.StepResultStatus, _ error) (context.Context, error) {
+			attachments = append(attachments, godog.Attachments(ctx)...)
+			return ctx, nil
+		})
+	})
+
+	return attachments
+}
+
+func TestRegisterReporter(t *testing.T) {
+	feature := `
+Feature: reporting
+
+  Scenario: attach the request of a failing step
+    Given I create a "POST" request to "/nodes"
+    And I set the request header "Authorization" to "Bearer secret"
+    And I set the request header "X-Test" to "it's"
+    And I set the request body parameter "name" to "Pump 1"
+    When I execute the request
+    Then the response code should be 201
+    And the response value ".data[0].body.name" should equal "Pump 2"
+`
+
+	attachments := runReportedFeature(t, feature)
+	require.Len(t, attachments, 3)
+
+	curl := string(attachments[0].Body)
+	require.Equal(t, "request.sh", attachments[0].FileName)
+	require.Contains(t, curl, "curl -X POST 'http://127.0.0.1:")
+	require.Contains(t, curl, "/nodes' \\\n  -H 'Authorization: <redacted>' \\\n")
+	require.Contains(t, curl, `-H 'X-Test: it'\''s'`)
+	require.Contains(t, curl, `--data-raw '{"name":"Pump 1"}'`)
+	require.NotContains(t, curl, "secret")
+
+	require.Equal(t, "response-headers.txt", attachments[1].FileName)
+	require.Contains(t, string(attachments[1].Body), "HTTP/1.1 201 Created\nContent-Length: ")
+	require.Contains(t, string(attachments[1].Body), "Content-Type: application/json\n")
+
+	require.Equal(t, "response-body.json", attachments[2].FileName)
+	require.Equal(t, "application/json", attachments[2].MediaType)
+	require.Contains(t, string(attachments[2].Body), "{\n  \"data\": [\n    {\n")
+}
+
+func TestRegisterReporter_Options(t *testing.T) {
+	feature := `
+Feature: reporting
+
+  Scenario: attach the request of a failing step
+    Given I create a "GET" request to "/nodes"
+    And I set the request header "X-Test" to "abc"
+    When I execute the request
+    Then the response code should be 200
+`
+
+	attachments := runReportedFeature(t, feature, api_godog.WithRedactedHeaders("x-test"), api_godog.WithMaxReportBody(10))
+	require.Len(t, attachments, 3)
+
+	require.Contains(t, string(attachments[0].Body), "-H 'X-Test: <redacted>'")
+
+	require.Equal(t, "response-body.txt", attachments[2].FileName)
+	require.Equal(t, "text/plain", attachments[2].MediaType)
+	require.Regexp(t, "^\\{\n  \"data\"\\.\\.\\. \\(truncated, 10 of \\d+ bytes shown\\)$", string(attachments[2].Body))
+}
+
+func TestRegisterReporter_PassingSteps(t *testing.T) {
+	feature := `
+Feature: reporting
+
+  Scenario: nothing to attach
+    Given I create a "GET" request to "/nodes"
+    When I execute the request
+    Then the response code should be 201
+`
+
+	require.Empty(t, runReportedFeature(t, feature))
+}
+
+func TestRegisterReporter_SoftAssertions(t *testing.T) {
+	feature := `
+Feature: reporting
+
+  @soft-assertions
+  Scenario: attach the request of a step with a failing soft assertion
+    Given I create a "GET" request to "/nodes"
+    When I execute the request
+    Then the response code should be 200
+    And the response code should be 201
+`
+
+	attachments := runReportedFeature(t, feature)
+	require.Len(t, attachments, 3)
+	require.Equal(t, "request.sh", attachments[0].FileName)
+}
+
+func TestRegisterReporter_TruncatesWholeCharacters(t *testing.T) {
+	feature := `
+Feature: reporting
+
+  Scenario: attach a truncated body
+    Given I create a "GET" request to "/nodes"
+    And I set the request header "X-Test" to "ååååå"
+    When I execute the request
+    Then the response code should be 200
+`
+
+	// The first å of the header starts at byte 55 of the pretty-printed body
+	for _, size := range []int{56, 57} {
+		attachments := runReportedFeature(t, feature, api_godog.WithMaxReportBody(size))
+		require.Len(t, attachments, 3)
+
+		body, _, found := strings.Cut(string(attachments[2].Body), "... (truncated")
+		require.True(t, found)
+		require.True(t, utf8.ValidString(body), body)
+		require.True(t, strings.HasSuffix(body, `"header": "`) || strings.HasSuffix(body, `"header": "å`), body)
+	}
+}
+
+func TestRegisterReporter_SavedExchange(t *testing.T) {
+	feature := `
+Feature: reporting
+
+  Scenario: attach the saved exchange of a failing step
+    Given I create a "POST" request to "/nodes"
+    When I execute the request as "createNode"
+    And I create a "GET" request to "/nodes/1"
+    And I execute the request
+    Then the response of createNode code should be 200
+`
+
+	attachments := runReportedFeature(t, feature)
+	require.Len(t, attachments, 3)
+	require.Contains(t, string(attachments[0].Body), "curl -X POST ")
+	require.Contains(t, string(attachments[2].Body), `"path": "/nodes"`)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -17,12 +18,11 @@ import (
 	http_model "github.com/SKF/go-utility/v2/http-model"
 	"github.com/SKF/go-utility/v2/log"
 	"github.com/pkg/errors"
-
-	dd_http "gopkg.in/DataDog/dd-trace-go.v1/contrib/net/http"
 )
 
 func (api *BaseFeature) CreatePathRequest(method, path string) error {
 	api.Request = Request{
+		Query:   make(url.Values),
 		Headers: make(http.Header),
 		Body:    make(map[string]interface{}),
 		Method:  method,
@@ -34,7 +34,7 @@ func (api *BaseFeature) CreatePathRequest(method, path string) error {
 
 func (api *BaseFeature) SetRequestHeaderParameterTo(key, value string) (err error) {
 	if strings.HasPrefix(value, ".") {
-		if value, err = api.GetValue(value); err != nil {
+		if value, err = api.value(value); err != nil {
 			return err
 		}
 	}
@@ -44,9 +44,27 @@ func (api *BaseFeature) SetRequestHeaderParameterTo(key, value string) (err erro
 	return
 }
 
+// SetRequestQueryParameterTo adds value to the query parameter key, setting
+// the same key more than once results in a multi-valued parameter.
+func (api *BaseFeature) SetRequestQueryParameterTo(key, value string) (err error) {
+	if strings.HasPrefix(value, ".") {
+		if value, err = api.value(value); err != nil {
+			return err
+		}
+	}
+
+	if api.Request.Query == nil {
+		api.Request.Query = make(url.Values)
+	}
+
+	api.Request.Query.Add(key, value)
+
+	return
+}
+
 func (api *BaseFeature) SetsRequestPathParameterTo(key, value string) (err error) {
 	if strings.HasPrefix(value, ".") {
-		if value, err = api.GetValue(value); err != nil {
+		if value, err = api.value(value); err != nil {
 			return err
 		}
 	}
@@ -63,68 +81,20 @@ func (api *BaseFeature) SetsRequestPathParameterTo(key, value string) (err error
 
 func (api *BaseFeature) SetRequestBodyParameterTo(key, value string) (err error) {
 	if strings.HasPrefix(value, ".") {
-		if value, err = api.GetValue(value); err != nil {
+		if value, err = api.value(value); err != nil {
 			return err
 		}
 	}
 
-	keyParts := strings.Split(key, ".")
-	prevMap := api.Request.Body
-
-	for idx, key := range keyParts {
-		if len(keyParts) == idx+1 {
-			prevMap[key] = value
-			break
-		}
-
-		if _, exists := prevMap[key]; !exists {
-			prevMap[key] = make(map[string]interface{})
-		}
-
-		prevMap = prevMap[key].(map[string]interface{})
-	}
-
-	return
+	return api.setBodyParameter(key, value)
 }
 
 func (api *BaseFeature) SetRequestBodyParameterToInt(key string, value int) (err error) {
-	prevMap := api.Request.Body
-
-	keyParts := strings.Split(key, ".")
-	for idx, key := range keyParts {
-		if len(keyParts) == idx+1 {
-			prevMap[key] = value
-			break
-		}
-
-		if _, exists := prevMap[key]; !exists {
-			prevMap[key] = make(map[string]interface{})
-		}
-
-		prevMap = prevMap[key].(map[string]interface{})
-	}
-
-	return
+	return api.setBodyParameter(key, value)
 }
 
 func (api *BaseFeature) SetRequestBodyParameterToFloat(key string, value float64) (err error) {
-	prevMap := api.Request.Body
-
-	keyParts := strings.Split(key, ".")
-	for idx, key := range keyParts {
-		if len(keyParts) == idx+1 {
-			prevMap[key] = value
-			break
-		}
-
-		if _, exists := prevMap[key]; !exists {
-			prevMap[key] = make(map[string]interface{})
-		}
-
-		prevMap = prevMap[key].(map[string]interface{})
-	}
-
-	return
+	return api.setBodyParameter(key, value)
 }
 
 func (api *BaseFeature) SetRequestBodyStringListParameterTo(key, valuesstr string) (err error) {
@@ -134,7 +104,7 @@ func (api *BaseFeature) SetRequestBodyStringListParameterTo(key, valuesstr strin
 	for _, value := range values {
 		value = strings.TrimSpace(value)
 		if strings.HasPrefix(value, ".") {
-			if value, err = api.GetValue(value); err != nil {
+			if value, err = api.value(value); err != nil {
 				return err
 			}
 		}
@@ -144,35 +114,40 @@ func (api *BaseFeature) SetRequestBodyStringListParameterTo(key, valuesstr strin
 		}
 	}
 
-	keyParts := strings.Split(key, ".")
-	prevMap := api.Request.Body
+	return api.setBodyParameter(key, list)
+}
 
-	for idx, key := range keyParts {
-		if len(keyParts) == idx+1 {
-			prevMap[key] = list
-			break
+// SetRequestBodyParameterToValue sets key to value parsed as a JSON literal,
+// e.g. `true`, `null`, `42` or `[{"name": "a"}]`. Variables referenced with
+// the `.` convention keep their type, and key may index into arrays as in
+// `items[0].name`.
+func (api *BaseFeature) SetRequestBodyParameterToValue(key, value string) error {
+	if strings.HasPrefix(value, ".") {
+		if variable, exists := api.Variables.Get(value); exists {
+			return api.setBodyParameter(key, variable)
 		}
 
-		if _, exists := prevMap[key]; !exists {
-			prevMap[key] = make(map[string]interface{})
+		resolved, err := api.value(value)
+		if err != nil {
+			return err
 		}
 
-		prevMap = prevMap[key].(map[string]interface{})
+		return api.setBodyParameter(key, resolved)
 	}
 
-	return
+	return api.setBodyParameter(key, parseBodyLiteral(value))
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntil(until retry.Until) error {
-	return api.ExecuteTheRequestUntilWithContextWithError(context.Background(), wrapUntilError(until))
+	return api.ExecuteTheRequestUntilWithContextWithError(api.context(), wrapUntilError(until))
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithError(until retry.UntilWithError) error {
-	return api.ExecuteTheRequestUntilWithContextWithError(context.Background(), until)
+	return api.ExecuteTheRequestUntilWithContextWithError(api.context(), until)
 }
 
 func (api *BaseFeature) ExecuteTheRequest() error {
-	return api.ExecuteTheRequestWithContext(context.Background())
+	return api.ExecuteTheRequestWithContext(api.context())
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithContext(ctx context.Context, until retry.Until) (err error) {
@@ -180,41 +155,33 @@ func (api *BaseFeature) ExecuteTheRequestUntilWithContext(ctx context.Context, u
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithContextWithError(ctx context.Context, until retry.UntilWithError) (err error) {
-	if api.Request.Method == http.MethodGet {
-		return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(ctx, nil, until)
-	}
-
-	jsonBody, err := json.Marshal(api.Request.Body)
+	payload, err := api.requestPayload()
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal failed")
+		return err
 	}
 
-	return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(ctx, jsonBody, until)
+	return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(ctx, payload, until)
 }
 
 func (api *BaseFeature) ExecuteTheRequestWithContext(ctx context.Context) (err error) {
-	if api.Request.Method == http.MethodGet {
-		return api.ExecuteTheRequestWithPayloadAndContext(ctx, nil)
-	}
-
-	jsonBody, err := json.Marshal(api.Request.Body)
+	payload, err := api.requestPayload()
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal failed")
+		return err
 	}
 
-	return api.ExecuteTheRequestWithPayloadAndContext(ctx, jsonBody)
+	return api.ExecuteTheRequestWithPayloadAndContext(ctx, payload)
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithPayload(payload []byte, until retry.Until) error {
-	return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(context.Background(), payload, wrapUntilError(until))
+	return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(api.context(), payload, wrapUntilError(until))
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithPayloadWithError(payload []byte, until retry.UntilWithError) error {
-	return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(context.Background(), payload, until)
+	return api.ExecuteTheRequestUntilWithPayloadAndContextWithError(api.context(), payload, until)
 }
 
 func (api *BaseFeature) ExecuteTheRequestWithPayload(payload []byte) error {
-	return api.ExecuteTheRequestWithPayloadAndContext(context.Background(), payload)
+	return api.ExecuteTheRequestWithPayloadAndContext(api.context(), payload)
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithPayloadAndContext(ctx context.Context, payload []byte, until retry.Until) (err error) {
@@ -222,16 +189,67 @@ func (api *BaseFeature) ExecuteTheRequestUntilWithPayloadAndContext(ctx context.
 }
 
 func (api *BaseFeature) ExecuteTheRequestUntilWithPayloadAndContextWithError(ctx context.Context, payload []byte, until retry.UntilWithError) (err error) {
-	return retry.Try(func() (bool, error) {
-		if err := api.ExecuteTheRequestWithPayloadAndContext(ctx, payload); err != nil {
-			return false, err
+	return api.ExecuteTheRequestUntilResponseWithPayloadAndContext(ctx, payload, retry.UntilResponse{
+		Condition: retry.BodyCondition(until.Condition),
+		Timeout:   until.Timeout,
+		Options:   until.Options,
+	})
+}
+
+// ExecuteTheRequestUntilResponse executes the request until the condition
+// on the whole response is met, e.g.
+// retry.And(retry.StatusIs(http.StatusOK), retry.JSONPathExists(".data.id")).
+func (api *BaseFeature) ExecuteTheRequestUntilResponse(until retry.UntilResponse) error {
+	return api.ExecuteTheRequestUntilResponseWithContext(api.context(), until)
+}
+
+func (api *BaseFeature) ExecuteTheRequestUntilResponseWithContext(ctx context.Context, until retry.UntilResponse) error {
+	payload, err := api.requestPayload()
+	if err != nil {
+		return err
+	}
+
+	return api.ExecuteTheRequestUntilResponseWithPayloadAndContext(ctx, payload, until)
+}
+
+// ExecuteTheRequestUntilResponseWithPayloadAndContext validates the contract
+// set with SetContract against the final response only, since responses
+// before the condition is met, e.g. a 404 of a resource that is still being
+// created, may not be declared.
+func (api *BaseFeature) ExecuteTheRequestUntilResponseWithPayloadAndContext(ctx context.Context, payload []byte, until retry.UntilResponse) error {
+	var req *http.Request
+
+	err := retry.DoUntilResponse(ctx, func(ctx context.Context) (retry.Response, error) {
+		var err error
+		if req, err = api.executeTheRequest(ctx, payload); err != nil {
+			return retry.Response{}, err
 		}
 
-		return until.Condition(api.Response.Body)
-	}, until.Timeout)
+		return retry.Response{
+			StatusCode: api.Response.Raw.StatusCode,
+			Header:     api.Response.Raw.Header,
+			Body:       api.Response.Body,
+			Duration:   api.Response.Duration,
+		}, nil
+	}, until)
+	if err != nil {
+		return err
+	}
+
+	return api.validateContract(ctx, req)
+}
+
+func (api *BaseFeature) ExecuteTheRequestWithPayloadAndContext(ctx context.Context, payload []byte) error {
+	req, err := api.executeTheRequest(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return api.validateContract(ctx, req)
 }
 
-func (api *BaseFeature) ExecuteTheRequestWithPayloadAndContext(ctx context.Context, payload []byte) (err error) {
+// executeTheRequest executes the request without validating the contract.
+func (api *BaseFeature) executeTheRequest(ctx context.Context, payload []byte) (*http.Request, error) {
 	log.Debugf("Request:  %s\n", api.Request.String())
 
 	if len(payload) > 0 {
@@ -243,47 +261,62 @@ func (api *BaseFeature) ExecuteTheRequestWithPayloadAndContext(ctx context.Conte
 		bodyBuffer = bytes.NewBuffer(payload)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, api.Request.Method, api.Request.Url, bodyBuffer)
+	resolvedURL, err := api.Request.ResolvedURL()
 	if err != nil {
-		return errors.Wrapf(err, "http.NewRequest failed - Payload: `%s`", string(payload))
+		return nil, err
 	}
 
-	req.Header = api.Request.Headers
+	req, err := http.NewRequestWithContext(ctx, api.Request.Method, resolvedURL, bodyBuffer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "http.NewRequest failed - Payload: `%s`", string(payload))
+	}
+
+	req.Header = api.Request.header()
 
+	api.Request.payload = payload
 	api.Request.ExecutionTime = time.Now()
-	client := dd_http.WrapClient(
-		&http.Client{},
-		dd_http.RTWithResourceNamer(func(req *http.Request) string {
-			return fmt.Sprintf("%s %s", req.Method, req.URL.String())
-		}),
-	)
-
-	resp, err := client.Do(req)
+	api.dumpRequest()
+
+	resp, err := api.httpClient().Do(req)
 	if err != nil {
-		return errors.Wrapf(err, "client.Do failed - header: `%+v`", req.Header)
+		return nil, errors.Wrapf(err, "client.Do failed - header: `%+v`", req.Header)
 	}
 
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return errors.Wrap(err, "io.ReadAll failed")
+		return nil, errors.Wrap(err, "io.ReadAll failed")
 	}
 
 	api.Response.Raw = resp
 	api.Response.Body = body
+	api.Response.Duration = time.Since(api.Request.ExecutionTime)
+	api.recordExchange()
 
 	log.Debugf("Response: %s", body)
 
-	return nil
+	return req, nil
+}
+
+func (api *BaseFeature) validateContract(ctx context.Context, req *http.Request) error {
+	if api.contract == nil {
+		return nil
+	}
+
+	return api.contract.Validate(ctx, req, api.Request.payload, api.Response.Raw, api.Response.Body)
 }
 
 func (api *BaseFeature) ExecuteInvalidRequest() error {
-	return api.ExecuteInvalidRequestWithContext(context.Background())
+	return api.ExecuteInvalidRequestWithContext(api.context())
 }
 
 func (api *BaseFeature) ExecuteInvalidRequestWithContext(ctx context.Context) error {
 	invalidBody := []byte(`{ "param": "value",}`)
+
+	// The body is invalid JSON, not the form or multipart body of an earlier
+	// execution
+	api.Request.contentType = contentTypeJSON
 	return api.ExecuteTheRequestWithPayloadAndContext(ctx, invalidBody)
 }
 
@@ -310,7 +343,7 @@ func (api *BaseFeature) AssertResponseBodyValueEquals(key, expected string) (err
 		return api.AssertDataLength(expected)
 	}
 
-	if expected, err = api.GetValue(expected); err != nil {
+	if expected, err = api.value(expected); err != nil {
 		return
 	}
 
@@ -378,5 +411,6 @@ func wrapUntilError(until retry.Until) retry.UntilWithError {
 			return until.Condition(body), nil
 		},
 		Timeout: until.Timeout,
+		Options: until.Options,
 	}
 }
@@ -55,3 +55,17 @@ func TestBaseFeature_SetRequestBodyParameterToInt(t *testing.T) {
 
 	require.Equal(t, 1, result.ID)
 }
+
+func TestBaseFeature_ExecuteTheRequest_InvalidURL(t *testing.T) {
+	api := BaseFeature{}
+	api.SetBaseUrl("http://localhost:%zz")
+
+	err := api.CreatePathRequest(http.MethodGet, "/nodes")
+	require.NoError(t, err)
+
+	err = api.SetRequestQueryParameterTo("limit", "10")
+	require.NoError(t, err)
+
+	err = api.ExecuteTheRequest()
+	require.ErrorContains(t, err, "invalid request url: http://localhost:%zz/nodes")
+}
@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// maxAttemptBody is how many bytes of a response body an Attempt keeps
+	maxAttemptBody = 256
+	// maxReportedAttempts is how many of the last attempts Error lists
+	maxReportedAttempts = 10
+)
+
+// Attempt is a call made while retrying, see Error.
+type Attempt struct {
+	Number int
+	Time   time.Time
+	// Duration is how long the call took
+	Duration time.Duration
+	// StatusCode and Body are only set when retrying requests, see
+	// DoUntilResponse. Body is truncated.
+	StatusCode int
+	Body       string
+	// Met tells whether the condition was met, Err is the error returned by
+	// the call or the condition
+	Met bool
+	Err error
+}
+
+func (a Attempt) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "#%d at %s (%s)", a.Number, a.Time.Format("15:04:05.000"), a.Duration.Round(time.Millisecond))
+
+	if a.StatusCode != 0 {
+		fmt.Fprintf(&b, " status %d", a.StatusCode)
+	}
+
+	switch {
+	case a.Err != nil:
+		fmt.Fprintf(&b, ": %s", a.Err)
+	case a.Met:
+		b.WriteString(": condition met")
+	default:
+		b.WriteString(": condition not met")
+	}
+
+	if a.Body != "" {
+		fmt.Fprintf(&b, ", body: %s", a.Body)
+	}
+
+	return b.String()
+}
+
+// Error is returned when retrying gives up, Attempts holds every call that
+// was made and the last ones are listed in the message.
+type Error struct {
+	Attempts []Attempt
+	err      error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.err.Error())
+
+	attempts := e.Attempts
+	if skipped := len(attempts) - maxReportedAttempts; skipped > 0 {
+		fmt.Fprintf(&b, "\n\t... %d earlier attempts", skipped)
+		attempts = attempts[skipped:]
+	}
+
+	for _, attempt := range attempts {
+		fmt.Fprintf(&b, "\n\t%s", attempt)
+	}
+
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
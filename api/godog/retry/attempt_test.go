@@ -0,0 +1,113 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+
+	"github.com/SKF/go-tests-utility/api/godog/retry"
+	"github.com/SKF/go-tests-utility/api/godog/tracecontext"
+)
+
+func Test_DoUntilResponseRecordsAttempts(t *testing.T) {
+	clock := &fakeClock{now: noon}
+
+	responses := []retry.Response{
+		{StatusCode: http.StatusServiceUnavailable, Body: []byte(`{"error": "starting"}`)},
+		{StatusCode: http.StatusOK, Body: []byte(`{"data": {"state": "pending"}}`)},
+		{StatusCode: http.StatusOK, Body: []byte(strings.Repeat("x", 300))},
+	}
+
+	calls := 0
+	err := retry.DoUntilResponse(context.Background(), func(context.Context) (retry.Response, error) {
+		resp := responses[calls]
+		calls++
+
+		return resp, nil
+	}, retry.UntilResponse{
+		Condition: retry.JSONPathEquals(".data.state", "ready"),
+		Timeout:   time.Minute,
+		Options:   []retry.Option{retry.WithMaxAttempts(3), retry.WithClock(clock)},
+	})
+
+	var retryErr *retry.Error
+	require.ErrorAs(t, err, &retryErr)
+	require.Len(t, retryErr.Attempts, 3)
+
+	require.Equal(t, 1, retryErr.Attempts[0].Number)
+	require.Equal(t, noon, retryErr.Attempts[0].Time)
+	require.Equal(t, http.StatusServiceUnavailable, retryErr.Attempts[0].StatusCode)
+	require.Equal(t, `{"error": "starting"}`, retryErr.Attempts[0].Body)
+	require.False(t, retryErr.Attempts[0].Met)
+
+	require.Equal(t, strings.Repeat("x", 256)+"... (truncated, 256 of 300 bytes shown)", retryErr.Attempts[2].Body)
+
+	require.Contains(t, err.Error(), "\n\t#2 at 12:00:00.100 (0s) status 200: condition not met, body: {\"data\": {\"state\": \"pending\"}}")
+}
+
+func Test_DoUntilResponseRecordsErrors(t *testing.T) {
+	errRefused := errors.New("connection refused")
+
+	err := retry.DoUntilResponse(context.Background(), func(context.Context) (retry.Response, error) {
+		return retry.Response{}, errRefused
+	}, retry.UntilResponse{
+		Condition: retry.StatusIs(http.StatusOK),
+		Timeout:   time.Minute,
+		Options: []retry.Option{
+			retry.WithMaxAttempts(2),
+			retry.WithRetryableErrors(func(error) bool { return true }),
+			retry.WithClock(&fakeClock{now: noon}),
+		},
+	})
+
+	require.ErrorIs(t, err, errRefused)
+	require.EqualError(t, err, `2 attempts made before condition was met: connection refused
+	#1 at 12:00:00.000 (0s): connection refused
+	#2 at 12:00:00.100 (0s): connection refused`)
+}
+
+func Test_ErrorListsLastAttempts(t *testing.T) {
+	err := retry.Do(context.Background(), failing(20, nil),
+		retry.WithBackoff(retry.Constant(time.Second)),
+		retry.WithMaxAttempts(15),
+		retry.WithClock(&fakeClock{now: noon}),
+	)
+
+	lines := strings.Split(err.Error(), "\n")
+	require.Len(t, lines, 12)
+	require.Equal(t, "\t... 5 earlier attempts", lines[1])
+	require.Equal(t, "\t#6 at 12:00:05.000 (0s): condition not met", lines[2])
+}
+
+func Test_AttemptsAreTraced(t *testing.T) {
+	t.Setenv(tracecontext.EnvTracerEnabled, "true")
+
+	tracer := mocktracer.Start()
+	defer tracer.Stop()
+
+	err := retry.DoUntilResponse(context.Background(), func(context.Context) (retry.Response, error) {
+		return retry.Response{StatusCode: http.StatusServiceUnavailable}, nil
+	}, retry.UntilResponse{
+		Condition: retry.StatusIs(http.StatusOK),
+		Timeout:   time.Minute,
+		Options:   []retry.Option{retry.WithMaxAttempts(2), retry.WithClock(&fakeClock{now: noon})},
+	})
+	require.Error(t, err)
+
+	spans := tracer.FinishedSpans()
+	require.Len(t, spans, 2)
+
+	for idx, span := range spans {
+		require.Equal(t, "retry.attempt", span.OperationName())
+		require.Equal(t, fmt.Sprintf("attempt %d", idx+1), span.Tag("resource.name"))
+		require.Equal(t, http.StatusServiceUnavailable, span.Tag("http.status_code"))
+		require.Equal(t, false, span.Tag("retry.condition_met"))
+	}
+}
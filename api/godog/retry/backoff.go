@@ -0,0 +1,57 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff returns how long to wait before retry number retry, starting at
+// zero, given the previous wait which is zero before the first retry.
+type Backoff func(retry int, previous time.Duration) time.Duration
+
+// Constant waits interval before every retry.
+func Constant(interval time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration {
+		return interval
+	}
+}
+
+// Linear waits initial before the first retry and increment longer before
+// every following retry.
+func Linear(initial, increment time.Duration) Backoff {
+	return func(retry int, _ time.Duration) time.Duration {
+		return initial + time.Duration(retry)*increment
+	}
+}
+
+// Exponential waits initial before the first retry and factor times longer
+// before every following retry, up to the longest time.Duration.
+func Exponential(initial time.Duration, factor float64) Backoff {
+	return func(retry int, _ time.Duration) time.Duration {
+		sleep := math.Pow(factor, float64(retry)) * float64(initial)
+		if sleep >= math.MaxInt64 {
+			return math.MaxInt64
+		}
+
+		return time.Duration(sleep)
+	}
+}
+
+// DecorrelatedJitter waits a random duration between base and three times
+// the previous wait, which spreads out retries of concurrent clients. Use
+// WithMaxInterval to cap it.
+func DecorrelatedJitter(base time.Duration) Backoff {
+	return func(_ int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+
+		upper := 3 * previous
+		if upper <= base {
+			return base
+		}
+
+		return base + time.Duration(rand.Int63n(int64(upper-base))) // nolint: gosec
+	}
+}
@@ -0,0 +1,128 @@
+package retry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// Response is the outcome of an attempt that a Condition is evaluated on.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Duration is how long the request took
+	Duration time.Duration
+}
+
+// Condition reports whether a response is the one being waited for, an
+// error stops retrying unless it is retryable, see WithRetryableErrors.
+type Condition func(resp Response) (bool, error)
+
+type UntilResponse struct {
+	Condition Condition
+	// Timeout zero makes a single attempt, like for Until
+	Timeout time.Duration
+	// Options change how the request is retried, e.g. WithBackoff
+	Options []Option
+}
+
+// StatusIs is met when the status code is one of codes.
+func StatusIs(codes ...int) Condition {
+	return func(resp Response) (bool, error) {
+		for _, code := range codes {
+			if resp.StatusCode == code {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// HeaderEquals is met when the header name has value.
+func HeaderEquals(name, value string) Condition {
+	return func(resp Response) (bool, error) {
+		return resp.Header.Get(name) == value, nil
+	}
+}
+
+// HeaderExists is met when the header name is present.
+func HeaderExists(name string) Condition {
+	return func(resp Response) (bool, error) {
+		return len(resp.Header.Values(name)) > 0, nil
+	}
+}
+
+// JSONPathEquals is met when the body has the scalar value at path.
+func JSONPathEquals(path, value string) Condition {
+	return func(resp Response) (bool, error) {
+		actual, err := json_matcher.Read(resp.Body, path)
+		return err == nil && actual == value, nil
+	}
+}
+
+// JSONPathExists is met when the body has a value at path.
+func JSONPathExists(path string) Condition {
+	return func(resp Response) (bool, error) {
+		return json_matcher.KeyIsPresent(resp.Body, path) == nil, nil
+	}
+}
+
+// ArrayLenAtLeast is met when the body has an array of at least length
+// elements at path.
+func ArrayLenAtLeast(path string, length int) Condition {
+	return func(resp Response) (bool, error) {
+		return json_matcher.ArrayLenAtLeast(resp.Body, path, length) == nil, nil
+	}
+}
+
+// BodyCondition adapts a condition on the body, as used by Until.
+func BodyCondition(condition func(body []byte) (bool, error)) Condition {
+	return func(resp Response) (bool, error) {
+		return condition(resp.Body)
+	}
+}
+
+// And is met when all conditions are, the first error is returned.
+func And(conditions ...Condition) Condition {
+	return func(resp Response) (bool, error) {
+		for _, condition := range conditions {
+			ok, err := condition(resp)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+}
+
+// Or is met when any of the conditions is, the first error is returned.
+func Or(conditions ...Condition) Condition {
+	return func(resp Response) (bool, error) {
+		for _, condition := range conditions {
+			ok, err := condition(resp)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// Not is met when condition isn't, errors are passed on.
+func Not(condition Condition) Condition {
+	return func(resp Response) (bool, error) {
+		ok, err := condition(resp)
+		if err != nil {
+			return false, errors.Wrap(err, "Not")
+		}
+
+		return !ok, nil
+	}
+}
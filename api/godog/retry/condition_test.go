@@ -0,0 +1,71 @@
+package retry_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SKF/go-tests-utility/api/godog/retry"
+)
+
+var conditionResponse = retry.Response{
+	StatusCode: http.StatusOK,
+	Header:     http.Header{"Etag": {`"v2"`}},
+	Body:       []byte(`{"data": {"id": "node-1", "children": [1, 2]}}`),
+}
+
+func met(t *testing.T, condition retry.Condition) bool {
+	t.Helper()
+
+	ok, err := condition(conditionResponse)
+	require.NoError(t, err)
+
+	return ok
+}
+
+func Test_Conditions(t *testing.T) {
+	assert.True(t, met(t, retry.StatusIs(http.StatusOK)))
+	assert.True(t, met(t, retry.StatusIs(http.StatusCreated, http.StatusOK)))
+	assert.False(t, met(t, retry.StatusIs(http.StatusNotFound)))
+
+	assert.True(t, met(t, retry.HeaderEquals("ETag", `"v2"`)))
+	assert.False(t, met(t, retry.HeaderEquals("ETag", `"v1"`)))
+	assert.True(t, met(t, retry.HeaderExists("ETag")))
+	assert.False(t, met(t, retry.HeaderExists("Location")))
+
+	assert.True(t, met(t, retry.JSONPathEquals(".data.id", "node-1")))
+	assert.False(t, met(t, retry.JSONPathEquals(".data.id", "node-2")))
+	assert.False(t, met(t, retry.JSONPathEquals(".data.missing", "")))
+	assert.True(t, met(t, retry.JSONPathExists(".data.children")))
+	assert.False(t, met(t, retry.JSONPathExists(".data.parent")))
+
+	assert.True(t, met(t, retry.ArrayLenAtLeast(".data.children", 2)))
+	assert.False(t, met(t, retry.ArrayLenAtLeast(".data.children", 3)))
+	assert.False(t, met(t, retry.ArrayLenAtLeast(".data.id", 0)))
+}
+
+func Test_ConditionComposition(t *testing.T) {
+	ok200 := retry.StatusIs(http.StatusOK)
+	notFound := retry.StatusIs(http.StatusNotFound)
+
+	assert.True(t, met(t, retry.And(ok200, retry.JSONPathExists(".data.id"))))
+	assert.False(t, met(t, retry.And(ok200, notFound)))
+	assert.True(t, met(t, retry.Or(notFound, ok200)))
+	assert.False(t, met(t, retry.Or(notFound, retry.HeaderExists("Location"))))
+	assert.True(t, met(t, retry.Not(notFound)))
+	assert.True(t, met(t, retry.And()))
+	assert.False(t, met(t, retry.Or()))
+
+	errFailed := errors.New("failed")
+	failing := retry.BodyCondition(func([]byte) (bool, error) {
+		return false, errFailed
+	})
+
+	for _, condition := range []retry.Condition{retry.And(ok200, failing), retry.Or(notFound, failing), retry.Not(failing)} {
+		_, err := condition(conditionResponse)
+		assert.ErrorIs(t, err, errFailed)
+	}
+}
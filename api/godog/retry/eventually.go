@@ -0,0 +1,72 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Eventually calls assertion every interval until it passes, and returns
+// the last failure when it hasn't passed within timeout, a zero timeout calls
+// it once. Options such as WithClock or WithMaxAttempts can be added.
+func Eventually(timeout, interval time.Duration, assertion func() error, opts ...Option) error {
+	return EventuallyWithContext(context.Background(), timeout, interval, assertion, opts...)
+}
+
+// EventuallyWithContext is Eventually that also stops when ctx is done.
+func EventuallyWithContext(ctx context.Context, timeout, interval time.Duration, assertion func() error, opts ...Option) error {
+	opts = append([]Option{
+		WithBackoff(Constant(interval)),
+		WithTimeout(timeout),
+		WithRetryableErrors(func(error) bool { return true }),
+	}, opts...)
+
+	return Do(ctx, func() (bool, error) {
+		err := assertion()
+		return err == nil, err
+	}, opts...)
+}
+
+// Consistently calls assertion every interval for duration and returns the
+// first failure. WithClock is the only option it supports, other options are
+// rejected.
+func Consistently(duration, interval time.Duration, assertion func() error, opts ...Option) error {
+	return ConsistentlyWithContext(context.Background(), duration, interval, assertion, opts...)
+}
+
+// ConsistentlyWithContext is Consistently that returns an error when ctx is
+// done before duration has passed.
+func ConsistentlyWithContext(ctx context.Context, duration, interval time.Duration, assertion func() error, opts ...Option) error {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.backoff != nil || c.maxInterval != 0 || c.maxAttempts != 0 || c.hasTimeout || c.retryable != nil {
+		return errors.New("retry.Consistently only supports the WithClock option")
+	}
+
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
+
+	start := c.clock.Now()
+	endBefore := start.Add(duration)
+
+	for attempt := 1; ; attempt++ {
+		if err := assertion(); err != nil {
+			return errors.Wrapf(err, "condition stopped holding after %s, attempt %d", c.clock.Now().Sub(start), attempt)
+		}
+
+		if c.clock.Now().Add(interval).After(endBefore) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "context done after %s, attempt %d", c.clock.Now().Sub(start), attempt)
+		case <-c.clock.After(interval):
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/SKF/go-tests-utility/api/godog/retry"
+)
+
+func Test_Eventually(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	attempts := 0
+	err := retry.Eventually(time.Second, 100*time.Millisecond, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not yet")
+		}
+
+		return nil
+	}, retry.WithClock(clock))
+
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}, clock.sleeps)
+}
+
+func Test_EventuallyTimeout(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	attempts := 0
+	err := retry.Eventually(time.Second, 300*time.Millisecond, func() error {
+		attempts++
+		return errors.New("attempt failed")
+	}, retry.WithClock(clock))
+
+	require.ErrorContains(t, err, "timeout <1s> reached before condition was met, #retries = 3: attempt failed\n")
+	require.Equal(t, 4, attempts)
+}
+
+func Test_Consistently(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	attempts := 0
+	err := retry.Consistently(time.Second, 250*time.Millisecond, func() error {
+		attempts++
+		return nil
+	}, retry.WithClock(clock))
+
+	require.NoError(t, err)
+	require.Equal(t, 5, attempts)
+
+	attempts = 0
+	err = retry.Consistently(time.Second, 250*time.Millisecond, func() error {
+		attempts++
+		if attempts == 3 {
+			return errors.New("changed")
+		}
+
+		return nil
+	}, retry.WithClock(clock))
+
+	require.EqualError(t, err, "condition stopped holding after 500ms, attempt 3: changed")
+
+	err = retry.Consistently(time.Second, 250*time.Millisecond, func() error {
+		return nil
+	}, retry.WithMaxAttempts(2))
+	require.EqualError(t, err, "retry.Consistently only supports the WithClock option")
+}
+
+func Test_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := retry.EventuallyWithContext(ctx, time.Hour, time.Minute, func() error {
+		attempts++
+		return errors.New("not yet")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = retry.ConsistentlyWithContext(ctx, time.Hour, time.Minute, func() error {
+		attempts++
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = retry.TryWithContext(ctx, func() (bool, error) {
+		attempts++
+		return false, nil
+	}, 2*time.Hour)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Equal(t, 3, attempts)
+}
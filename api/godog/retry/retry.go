@@ -1,50 +1,275 @@
 package retry
 
 import (
+	"context"
 	"fmt"
-	"math"
 	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/SKF/go-utility/v2/log"
+
+	"github.com/SKF/go-tests-utility/api/godog/internal/truncate"
+	"github.com/SKF/go-tests-utility/api/godog/tracecontext"
 )
 
+// DefaultTimeout is how long Do retries when neither WithTimeout nor
+// WithMaxAttempts is given.
+const DefaultTimeout = time.Minute
+
 const (
 	startingMillisToWait = 100
 	powBase              = 2
+
+	attemptOperationName = "retry.attempt"
+	tagAttemptNumber     = "retry.attempt"
+	tagAttemptMet        = "retry.condition_met"
+	tagAttemptStatusCode = "http.status_code"
 )
 
 type Until struct {
 	Condition func(body []byte) bool
 	Timeout   time.Duration
+	// Options change how the request is retried, e.g. WithBackoff
+	Options []Option
 }
 
 type UntilWithError struct {
 	Condition func(body []byte) (bool, error)
 	Timeout   time.Duration
+	// Options change how the request is retried, e.g. WithBackoff
+	Options []Option
+}
+
+// Clock abstracts time so retries can be tested without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type config struct {
+	backoff     Backoff
+	maxInterval time.Duration
+	maxAttempts int
+	timeout     time.Duration
+	hasTimeout  bool
+	retryable   func(error) bool
+	clock       Clock
 }
 
+type Option func(*config)
+
+// WithBackoff sets the strategy deciding how long to wait between attempts,
+// Exponential(100*time.Millisecond, 2) by default.
+func WithBackoff(backoff Backoff) Option {
+	return func(c *config) {
+		c.backoff = backoff
+	}
+}
+
+// WithMaxInterval caps the wait between two attempts.
+func WithMaxInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.maxInterval = interval
+	}
+}
+
+// WithMaxAttempts stops retrying after attempts calls, zero means no limit.
+func WithMaxAttempts(attempts int) Option {
+	return func(c *config) {
+		c.maxAttempts = attempts
+	}
+}
+
+// WithTimeout stops retrying when the next attempt would start after timeout
+// has passed since the first one, zero makes a single attempt. Without it
+// and WithMaxAttempts the timeout is DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+		c.hasTimeout = true
+	}
+}
+
+// WithRetryableErrors makes errors for which retryable returns true be
+// retried instead of returned, by default every error is returned.
+func WithRetryableErrors(retryable func(error) bool) Option {
+	return func(c *config) {
+		c.retryable = retryable
+	}
+}
+
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		backoff:   Exponential(startingMillisToWait*time.Millisecond, powBase),
+		retryable: func(error) bool { return false },
+		clock:     realClock{},
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if !c.hasTimeout && c.maxAttempts <= 0 {
+		c.timeout, c.hasTimeout = DefaultTimeout, true
+	}
+
+	return c
+}
+
+// Try calls function with exponential backoff until it succeeds, returns an
+// error or timeout is reached.
 func Try(function func() (bool, error), timeout time.Duration) error {
-	nrOfRetries := 0
-	endBefore := time.Now().Add(timeout)
+	return TryWithContext(context.Background(), function, timeout)
+}
 
-	for {
-		success, err := function()
+// TryWithContext is Try that also stops when ctx is done.
+func TryWithContext(ctx context.Context, function func() (bool, error), timeout time.Duration) error {
+	return Do(ctx, function, WithTimeout(timeout))
+}
+
+// Do calls function until it succeeds, returns an error that isn't
+// retryable, the context is done or a limit set by the options is reached,
+// DefaultTimeout when no limit is set.
+// Every attempt is logged and, when tracing is enabled, traced as a child
+// span of the span in ctx. When giving up the attempts are returned in an
+// *Error.
+func Do(ctx context.Context, function func() (bool, error), opts ...Option) error {
+	return do(ctx, func(context.Context, *Attempt) (bool, error) {
+		return function()
+	}, newConfig(opts))
+}
+
+// DoUntilResponse calls execute until until.Condition is met by the
+// response, like Do it records the status code and body of every attempt.
+func DoUntilResponse(ctx context.Context, execute func(ctx context.Context) (Response, error), until UntilResponse) error {
+	opts := append([]Option{WithTimeout(until.Timeout)}, until.Options...)
+
+	return do(ctx, func(ctx context.Context, attempt *Attempt) (bool, error) {
+		resp, err := execute(ctx)
 		if err != nil {
-			return err
+			return false, err
 		}
 
-		if success {
+		attempt.StatusCode = resp.StatusCode
+		attempt.Body = string(truncate.Body(resp.Body, maxAttemptBody))
+
+		return until.Condition(resp)
+	}, newConfig(opts))
+}
+
+func do(ctx context.Context, function func(ctx context.Context, attempt *Attempt) (bool, error), c config) error {
+	var (
+		nrOfRetries int
+		lastErr     error
+		sleep       time.Duration
+		attempts    []Attempt
+		endBefore   = c.clock.Now().Add(c.timeout)
+	)
+
+	giveUp := func(err error, format string, args ...interface{}) error {
+		return &Error{Attempts: attempts, err: wrapLastErr(err, format, args...)}
+	}
+
+	for {
+		attempt := call(ctx, nrOfRetries+1, function, c.clock)
+		attempts = append(attempts, attempt)
+
+		if attempt.Err != nil && !c.retryable(attempt.Err) {
+			return attempt.Err
+		}
+
+		if attempt.Met {
 			return nil
 		}
 
-		sleepDuration := time.Duration(math.Pow(powBase, float64(nrOfRetries))*startingMillisToWait) * time.Millisecond
-		nextRuntime := time.Now().Add(sleepDuration)
+		lastErr = attempt.Err
+
+		if c.maxAttempts > 0 && nrOfRetries+1 >= c.maxAttempts {
+			return giveUp(lastErr, "%d attempts made before condition was met", nrOfRetries+1)
+		}
+
+		sleep = nextSleep(c, nrOfRetries, sleep)
 
-		if nextRuntime.After(endBefore) {
-			break
+		if c.hasTimeout && (c.timeout <= 0 || c.clock.Now().Add(sleep).After(endBefore)) {
+			return giveUp(lastErr, "timeout <%s> reached before condition was met, #retries = %d", c.timeout, nrOfRetries)
+		}
+
+		select {
+		case <-ctx.Done():
+			return giveUp(ctx.Err(), "context done before condition was met, #retries = %d", nrOfRetries)
+		case <-c.clock.After(sleep):
 		}
 
-		time.Sleep(sleepDuration)
 		nrOfRetries++
 	}
+}
+
+// nextSleep returns the wait before retry number retry capped by the max
+// interval. A wait that isn't positive, e.g. of a backoff that overflowed, is
+// replaced by the max interval or else 100ms.
+func nextSleep(c config, retry int, previous time.Duration) time.Duration {
+	sleep := c.backoff(retry, previous)
+
+	if sleep <= 0 {
+		sleep = c.maxInterval
+		if sleep <= 0 {
+			sleep = startingMillisToWait * time.Millisecond
+		}
+	}
+
+	if c.maxInterval > 0 && sleep > c.maxInterval {
+		sleep = c.maxInterval
+	}
+
+	return sleep
+}
+
+func call(ctx context.Context, number int, function func(ctx context.Context, attempt *Attempt) (bool, error), clock Clock) Attempt {
+	ctx, finish := tracecontext.StartSpan(ctx, attemptOperationName, fmt.Sprintf("attempt %d", number))
+
+	attempt := Attempt{Number: number, Time: clock.Now()}
+
+	success, err := function(ctx, &attempt)
+
+	attempt.Duration = clock.Now().Sub(attempt.Time)
+	attempt.Met = err == nil && success
+	attempt.Err = err
+
+	log.Debugf("Retry attempt %s", attempt)
+
+	tracecontext.SetTag(ctx, tagAttemptNumber, number)
+	tracecontext.SetTag(ctx, tagAttemptMet, attempt.Met)
+
+	if attempt.StatusCode != 0 {
+		tracecontext.SetTag(ctx, tagAttemptStatusCode, attempt.StatusCode)
+	}
+
+	finish(err)
+
+	return attempt
+}
+
+func wrapLastErr(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return errors.Errorf(format, args...)
+	}
 
-	return fmt.Errorf("timeout <%s> reached before condition was met, #retries = %d", timeout, nrOfRetries)
+	return errors.Wrapf(err, format, args...)
 }
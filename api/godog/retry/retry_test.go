@@ -1,6 +1,9 @@
 package retry_test
 
 import (
+	"context"
+	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -75,3 +78,220 @@ func Test_Retries(t *testing.T) {
 		})
 	}
 }
+
+var noon = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeClock returns from After immediately and records the requested waits.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+func failing(attemptsNeeded int, err error) func() (bool, error) {
+	attempts := 0
+
+	return func() (bool, error) {
+		attempts++
+		if attempts >= attemptsNeeded {
+			return true, nil
+		}
+
+		return false, err
+	}
+}
+
+func Test_Backoffs(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoff  retry.Backoff
+		expected []time.Duration
+	}{
+		{
+			name:     "constant",
+			backoff:  retry.Constant(time.Second),
+			expected: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:     "linear",
+			backoff:  retry.Linear(time.Second, 2*time.Second),
+			expected: []time.Duration{time.Second, 3 * time.Second, 5 * time.Second},
+		},
+		{
+			name:     "exponential",
+			backoff:  retry.Exponential(100*time.Millisecond, 3),
+			expected: []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Now()}
+
+			err := retry.Do(context.Background(), failing(4, nil), retry.WithBackoff(tt.backoff), retry.WithClock(clock))
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, clock.sleeps)
+		})
+	}
+}
+
+func Test_DecorrelatedJitter(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	err := retry.Do(context.Background(), failing(20, nil),
+		retry.WithBackoff(retry.DecorrelatedJitter(100*time.Millisecond)),
+		retry.WithMaxInterval(2*time.Second),
+		retry.WithClock(clock),
+	)
+	require.NoError(t, err)
+	require.Len(t, clock.sleeps, 19)
+
+	previous := 100 * time.Millisecond
+	for _, sleep := range clock.sleeps {
+		require.GreaterOrEqual(t, sleep, 100*time.Millisecond)
+		require.LessOrEqual(t, sleep, 2*time.Second)
+		require.LessOrEqual(t, sleep, 3*previous)
+
+		previous = sleep
+	}
+}
+
+func Test_MaxInterval(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+
+	err := retry.Do(context.Background(), failing(5, nil),
+		retry.WithBackoff(retry.Exponential(time.Second, 2)),
+		retry.WithMaxInterval(3*time.Second),
+		retry.WithClock(clock),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}, clock.sleeps)
+}
+
+func Test_MaxAttempts(t *testing.T) {
+	clock := &fakeClock{now: noon}
+
+	err := retry.Do(context.Background(), failing(5, nil), retry.WithMaxAttempts(3), retry.WithClock(clock))
+	require.EqualError(t, err, `3 attempts made before condition was met
+	#1 at 12:00:00.000 (0s): condition not met
+	#2 at 12:00:00.100 (0s): condition not met
+	#3 at 12:00:00.300 (0s): condition not met`)
+	require.Len(t, clock.sleeps, 2)
+}
+
+func Test_ExponentialDoesNotOverflow(t *testing.T) {
+	backoff := retry.Exponential(100*time.Millisecond, 2)
+	require.Equal(t, time.Duration(math.MaxInt64), backoff(100, 0))
+
+	clock := &fakeClock{now: noon}
+
+	err := retry.Do(context.Background(), failing(40, nil), retry.WithMaxInterval(time.Minute), retry.WithTimeout(time.Hour), retry.WithClock(clock))
+	require.NoError(t, err)
+
+	for _, sleep := range clock.sleeps[10:] {
+		require.Equal(t, time.Minute, sleep)
+	}
+}
+
+func Test_NonPositiveBackoff(t *testing.T) {
+	clock := &fakeClock{now: noon}
+
+	err := retry.Do(context.Background(), failing(3, nil), retry.WithBackoff(retry.Constant(-time.Second)), retry.WithClock(clock))
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}, clock.sleeps)
+}
+
+func Test_Timeout(t *testing.T) {
+	clock := &fakeClock{now: noon}
+
+	// Waits 100ms, 200ms and 400ms, the next wait of 800ms would pass the timeout
+	err := retry.Do(context.Background(), failing(10, nil), retry.WithTimeout(time.Second), retry.WithClock(clock))
+	require.EqualError(t, err, `timeout <1s> reached before condition was met, #retries = 3
+	#1 at 12:00:00.000 (0s): condition not met
+	#2 at 12:00:00.100 (0s): condition not met
+	#3 at 12:00:00.300 (0s): condition not met
+	#4 at 12:00:00.700 (0s): condition not met`)
+	require.Len(t, clock.sleeps, 3)
+}
+
+func Test_ZeroTimeoutMakesSingleAttempt(t *testing.T) {
+	attempts := 0
+
+	err := retry.Try(func() (bool, error) {
+		attempts++
+		return false, nil
+	}, 0)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "timeout <0s> reached")
+	require.Equal(t, 1, attempts)
+
+	clock := &fakeClock{now: noon}
+
+	err = retry.Do(context.Background(), failing(3, nil), retry.WithBackoff(retry.Constant(0)), retry.WithTimeout(0), retry.WithClock(clock))
+	require.Error(t, err)
+	require.Empty(t, clock.sleeps)
+}
+
+func Test_RetryableErrors(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	retryable := retry.WithRetryableErrors(func(err error) bool {
+		return errors.Is(err, errTemporary)
+	})
+
+	clock := &fakeClock{now: time.Now()}
+
+	err := retry.Do(context.Background(), failing(3, errTemporary), retryable, retry.WithClock(clock))
+	require.NoError(t, err)
+	require.Len(t, clock.sleeps, 2)
+
+	err = retry.Do(context.Background(), failing(3, errPermanent), retryable, retry.WithClock(clock))
+	require.ErrorIs(t, err, errPermanent)
+
+	err = retry.Do(context.Background(), failing(3, errTemporary), retryable, retry.WithMaxAttempts(2), retry.WithClock(&fakeClock{now: noon}))
+	require.ErrorIs(t, err, errTemporary)
+	require.EqualError(t, err, `2 attempts made before condition was met: temporary
+	#1 at 12:00:00.000 (0s): temporary
+	#2 at 12:00:00.100 (0s): temporary`)
+
+	err = retry.Do(context.Background(), failing(3, errTemporary), retry.WithClock(clock))
+	require.ErrorIs(t, err, errTemporary)
+}
+
+func Test_ContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := retry.Do(ctx, func() (bool, error) {
+		attempts++
+		cancel()
+
+		return false, nil
+	}, retry.WithBackoff(retry.Constant(time.Hour)), retry.WithMaxAttempts(2))
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, attempts)
+}
+
+func Test_DefaultTimeout(t *testing.T) {
+	clock := &fakeClock{now: noon}
+
+	err := retry.Do(context.Background(), failing(100, nil), retry.WithBackoff(retry.Constant(10*time.Second)), retry.WithClock(clock))
+	require.ErrorContains(t, err, "timeout <1m0s> reached before condition was met, #retries = 6")
+	require.Len(t, clock.sleeps, 6)
+}
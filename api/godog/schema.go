@@ -0,0 +1,25 @@
+package godog
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// AssertResponseMatchesSchema validates the response body against a JSON
+// Schema, given either inline or as a file relative to the fixtures directory.
+func (api *BaseFeature) AssertResponseMatchesSchema(schemaFileOrDocument string) error {
+	schema := strings.TrimSpace(schemaFileOrDocument)
+	if strings.HasPrefix(schema, "{") || schema == "true" || schema == "false" {
+		return json_matcher.MatchSchema(api.Response.Body, []byte(schema))
+	}
+
+	return json_matcher.MatchSchemaFile(api.Response.Body, filepath.Join(api.fixturesDir, schema))
+}
+
+func (api *BaseFeature) AssertResponseMatchesSchemaDocString(schema *godog.DocString) error {
+	return api.AssertResponseMatchesSchema(schema.Content)
+}
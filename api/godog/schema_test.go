@@ -0,0 +1,21 @@
+package godog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseFeature_AssertResponseMatchesSchema(t *testing.T) {
+	api := BaseFeature{}
+	api.SetFixturesDir("testdata/fixtures")
+	api.Response.Body = []byte(`{"id": "node-1"}`)
+
+	assert.NoError(t, api.AssertResponseMatchesSchema("node.schema.json"))
+	assert.NoError(t, api.AssertResponseMatchesSchema(`{"required": ["id"]}`))
+	assert.Error(t, api.AssertResponseMatchesSchema(`{"required": ["label"]}`))
+	assert.Error(t, api.AssertResponseMatchesSchema("missing.schema.json"))
+
+	api.Response.Body = []byte(`{"id": 1}`)
+	assert.Error(t, api.AssertResponseMatchesSchema("node.schema.json"))
+}
@@ -0,0 +1,62 @@
+package godog
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/SKF/go-tests-utility/api/godog/internal/scenario"
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// DefaultSnapshotsDir is where response snapshots are stored unless
+// SetSnapshotsDir is used.
+const DefaultSnapshotsDir = "testdata/__snapshots__"
+
+var unsafeSnapshotNameRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SetScenario tells BaseFeature which scenario is running, snapshots are
+// stored per feature and scenario, and so are the requests dumped by
+// RegisterRequestDump. RegisterSteps and RegisterRequestDump call it before
+// every scenario.
+func (api *BaseFeature) SetScenario(scenario *godog.Scenario) {
+	api.scenario = scenario
+	api.dumpedRequests = 0
+}
+
+func (api *BaseFeature) SetSnapshotsDir(dir string) {
+	api.snapshotsDir = dir
+}
+
+// SetSnapshotOptions sets the redactions used for every snapshot, e.g.
+// json_matcher.RedactUUIDs() or json_matcher.RedactPaths(".data.traceId").
+func (api *BaseFeature) SetSnapshotOptions(opts ...json_matcher.SnapshotOption) {
+	api.snapshotOptions = opts
+}
+
+// AssertResponseMatchesSnapshot compares the response body with the snapshot
+// name of the current scenario. The snapshot is created and updated when
+// UPDATE_SNAPSHOTS=true, a missing snapshot is an error otherwise.
+func (api *BaseFeature) AssertResponseMatchesSnapshot(name string) error {
+	return json_matcher.MatchSnapshot(api.Response.Body, api.snapshotFilename(name), api.snapshotOptions...)
+}
+
+// snapshotFilename returns <dir>/<feature>/<scenario>_<name>.json.
+func (api *BaseFeature) snapshotFilename(name string) string {
+	dir := api.snapshotsDir
+	if dir == "" {
+		dir = DefaultSnapshotsDir
+	}
+
+	if api.scenario == nil {
+		return filepath.Join(dir, snapshotName(name)+".json")
+	}
+
+	return filepath.Join(dir, snapshotName(scenario.Feature(api.scenario)), snapshotName(scenario.Name(api.scenario)+"_"+name)+".json")
+}
+
+func snapshotName(name string) string {
+	return strings.Trim(unsafeSnapshotNameRegexp.ReplaceAllString(name, "_"), "_")
+}
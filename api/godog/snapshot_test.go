@@ -0,0 +1,95 @@
+package godog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+func TestRegisterSteps_Snapshot(t *testing.T) {
+	t.Setenv(json_matcher.EnvUpdateSnapshots, "")
+
+	s := newEchoServer(t)
+	defer s.Close()
+
+	dir := t.TempDir()
+
+	feature := `
+Feature: snapshots
+
+  Scenario: Get a node
+    Given I create a "GET" request to "/nodes"
+    And I set the request header "X-Test" to "volatile"
+    When I execute the request
+    Then the response should match the snapshot "nodes"
+`
+
+	run := func() int {
+		return runFeature(t, feature, func(sc *godog.ScenarioContext) {
+			api := &api_godog.BaseFeature{}
+			api.SetBaseUrl(s.URL)
+			api.SetSnapshotsDir(dir)
+			api.SetSnapshotOptions(json_matcher.RedactPaths(".data[*].header"))
+
+			api_godog.RegisterSteps(sc, api)
+		})
+	}
+
+	filename := filepath.Join(dir, "steps", "Get_a_node_nodes.json")
+
+	require.Equal(t, 1, run())
+	require.NoFileExists(t, filename)
+
+	t.Setenv(json_matcher.EnvUpdateSnapshots, "true")
+	require.Equal(t, 0, run())
+	require.FileExists(t, filename)
+
+	t.Setenv(json_matcher.EnvUpdateSnapshots, "")
+	require.Equal(t, 0, run())
+
+	require.NoError(t, os.WriteFile(filename, []byte(`{"data":[]}`), 0600))
+	require.Equal(t, 1, run())
+}
+
+func TestRegisterSteps_SnapshotOutline(t *testing.T) {
+	t.Setenv(json_matcher.EnvUpdateSnapshots, "true")
+
+	s := newEchoServer(t)
+	defer s.Close()
+
+	dir := t.TempDir()
+
+	feature := `
+Feature: snapshots
+
+  Scenario Outline: Get a node
+    Given I create a "GET" request to "/nodes/<id>"
+    When I execute the request
+    Then the response should match the snapshot "node"
+
+    Examples:
+      | id |
+      | 1  |
+      | 2  |
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+		api.SetSnapshotsDir(dir)
+		api.SetSnapshotOptions(json_matcher.RedactPaths(".data[*].header"))
+
+		api_godog.RegisterSteps(sc, api)
+	})
+	require.Equal(t, 0, status)
+
+	snapshots, err := filepath.Glob(filepath.Join(dir, "steps", "Get_a_node_*_node.json"))
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+}
@@ -0,0 +1,152 @@
+package godog
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	"github.com/SKF/go-tests-utility/api/godog/tracecontext"
+)
+
+// SoftAssertionsTag makes a scenario use SoftAssertionsPerScenario.
+const SoftAssertionsTag = "@soft-assertions"
+
+// AssertionMode decides when failing assertions are reported, see
+// SetAssertionMode.
+type AssertionMode int
+
+const (
+	// HardAssertions fail the step on the first failing assertion.
+	HardAssertions AssertionMode = iota
+	// SoftAssertionsPerStep collect the failing assertions of a step and
+	// fail the step with all of them once it has run.
+	SoftAssertionsPerStep
+	// SoftAssertionsPerScenario let the scenario run to the end and fail
+	// its last step with every failing assertion.
+	SoftAssertionsPerScenario
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+type softFailure struct {
+	step string
+	err  error
+}
+
+type softAssertions struct {
+	mode     AssertionMode
+	active   AssertionMode
+	step     string
+	failures []softFailure
+	// stepFailed is whether an assertion of the current step was collected
+	stepFailed bool
+}
+
+// SetAssertionMode sets whether failing assertions fail the step right away,
+// the default, or are collected and reported after the step or scenario.
+// Steps named Assert* and errors passed to SoftAssert are collected.
+func (api *BaseFeature) SetAssertionMode(mode AssertionMode) {
+	api.soft.mode = mode
+	api.soft.active = mode
+}
+
+// SoftAssert returns err when assertions are hard, otherwise it collects err
+// to be reported later and returns nil, e.g.
+// `return api.SoftAssert(api.AssertResponseCode(http.StatusOK))`. The span of
+// the step is marked as failed when tracing is enabled.
+func (api *BaseFeature) SoftAssert(err error) error {
+	if err == nil || api.soft.active == HardAssertions {
+		return err
+	}
+
+	api.soft.failures = append(api.soft.failures, softFailure{step: api.soft.step, err: err})
+	api.soft.stepFailed = true
+	tracecontext.SetError(api.context(), err)
+
+	return nil
+}
+
+// RegisterSoftAssertions adds the hooks reporting soft assertions to the
+// scenario context, RegisterSteps does so as well. Register it before
+// tracecontext.New for the scenario span to be marked as failed.
+func RegisterSoftAssertions(sc *godog.ScenarioContext, api *BaseFeature) {
+	sc.Before(func(ctx context.Context, scenario *godog.Scenario) (context.Context, error) {
+		api.soft.failures = nil
+		api.soft.active = api.soft.mode
+
+		for _, tag := range scenario.Tags {
+			if tag.Name == SoftAssertionsTag {
+				api.soft.active = SoftAssertionsPerScenario
+			}
+		}
+
+		return ctx, nil
+	})
+
+	sc.StepContext().Before(func(ctx context.Context, step *godog.Step) (context.Context, error) {
+		api.soft.step = step.Text
+		api.soft.stepFailed = false
+
+		return ctx, nil
+	})
+
+	sc.StepContext().After(func(ctx context.Context, step *godog.Step, status godog.StepResultStatus, err error) (context.Context, error) {
+		if api.soft.active != SoftAssertionsPerStep {
+			return ctx, nil
+		}
+
+		return ctx, api.softAssertionsError()
+	})
+
+	sc.After(func(ctx context.Context, scenario *godog.Scenario, err error) (context.Context, error) {
+		return ctx, api.softAssertionsError()
+	})
+}
+
+// softAssertionsError returns an error listing the collected failures and
+// clears them.
+func (api *BaseFeature) softAssertionsError() error {
+	failures := api.soft.failures
+	api.soft.failures = nil
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		lines = append(lines, fmt.Sprintf("%s: %s", failure.step, failure.err))
+	}
+
+	return errors.Errorf("Soft assertion error: %d assertion(s) failed:\n\t%s", len(failures), strings.Join(lines, "\n\t"))
+}
+
+// softened wraps a step function returning an error so that the error is
+// passed through SoftAssert.
+func (api *BaseFeature) softened(fn interface{}) interface{} {
+	value := reflect.ValueOf(fn)
+	typ := value.Type()
+
+	if typ.NumOut() == 0 || typ.Out(typ.NumOut()-1) != errorType {
+		return fn
+	}
+
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		results := value.Call(args)
+
+		last := len(results) - 1
+		if err, _ := results[last].Interface().(error); err != nil {
+			results[last] = reflect.Zero(errorType)
+
+			if err = api.SoftAssert(err); err != nil {
+				results[last] = reflect.ValueOf(&err).Elem()
+			}
+		}
+
+		return results
+	}).Interface()
+}
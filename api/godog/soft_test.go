@@ -0,0 +1,124 @@
+package godog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+	dd_tracer "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+	"github.com/SKF/go-tests-utility/api/godog/tracecontext"
+)
+
+func TestRegisterSteps_SoftAssertionsPerScenario(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := `
+Feature: soft assertions
+
+  @soft-assertions
+  Scenario: report every mismatch
+    Given I create a "POST" request to "/nodes"
+    When I execute the request
+    Then the response code should be 201
+    And the response value ".data[0].method" should equal "PUT"
+    And the response value ".data[0].path" should equal "/nodes"
+    And the response value ".data[0].path" should start with "/assets"
+`
+
+	var scenarioErr error
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+
+		sc.After(func(ctx context.Context, _ *godog.Scenario, err error) (context.Context, error) {
+			scenarioErr = err
+			return ctx, nil
+		})
+	})
+
+	require.Equal(t, 1, status)
+	require.EqualError(t, scenarioErr, `Soft assertion error: 2 assertion(s) failed:
+	the response value ".data[0].method" should equal "PUT": Match error: Values mismatch, expected: 'PUT' actual: 'POST'
+	the response value ".data[0].path" should start with "/assets": Match error: Expected '/nodes' starts with '/assets'`)
+}
+
+func TestSoftAssertionsPerStep(t *testing.T) {
+	feature := `
+Feature: soft assertions
+
+  Scenario: check many values in one step
+    Given the node is valid
+    And the node is valid
+`
+
+	var (
+		stepErrs []error
+		steps    int
+	)
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetAssertionMode(api_godog.SoftAssertionsPerStep)
+		api.Response.Body = []byte(`{"data": {"name": "Pump 1", "criticality": 3}}`)
+
+		api_godog.RegisterSoftAssertions(sc, api)
+
+		sc.Step(`^the node is valid$`, func() error {
+			steps++
+
+			_ = api.SoftAssert(api.AssertResponseBodyValueEquals(".data.name", "Pump 2"))
+			_ = api.SoftAssert(api.AssertResponseBodyValueEquals(".data.criticality", "3"))
+
+			return api.SoftAssert(api.AssertResponseValueBetween(".data.criticality", 4, 5))
+		})
+
+		sc.StepContext().After(func(ctx context.Context, _ *godog.Step, _ godog.StepResultStatus, err error) (context.Context, error) {
+			stepErrs = append(stepErrs, err)
+			return ctx, nil
+		})
+	})
+
+	require.Equal(t, 1, status)
+	require.Equal(t, 1, steps, "the scenario stops at the failing step")
+	require.NotEmpty(t, stepErrs)
+	require.ErrorContains(t, stepErrs[0], "Soft assertion error: 2 assertion(s) failed:\n\tthe node is valid: Match error: Values mismatch")
+}
+
+func TestSoftAssertHardByDefault(t *testing.T) {
+	api := &api_godog.BaseFeature{}
+
+	errMismatch := errors.New("mismatch")
+	require.ErrorIs(t, api.SoftAssert(errMismatch), errMismatch)
+
+	api.SetAssertionMode(api_godog.SoftAssertionsPerScenario)
+	require.NoError(t, api.SoftAssert(errMismatch))
+}
+
+func TestSoftAssertMarksSpan(t *testing.T) {
+	t.Setenv(tracecontext.EnvTracerEnabled, "true")
+
+	tracer := mocktracer.Start()
+	defer tracer.Stop()
+
+	span, ctx := dd_tracer.StartSpanFromContext(context.Background(), "step")
+
+	api := &api_godog.BaseFeature{}
+	api.SetContext(ctx)
+	api.SetAssertionMode(api_godog.SoftAssertionsPerScenario)
+
+	require.NoError(t, api.SoftAssert(errors.New("mismatch")))
+	span.Finish()
+
+	spans := tracer.FinishedSpans()
+	require.Len(t, spans, 1)
+	require.EqualError(t, spans[0].Tag("error").(error), "mismatch")
+}
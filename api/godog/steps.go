@@ -0,0 +1,284 @@
+package godog
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	"github.com/SKF/go-tests-utility/api/godog/retry"
+)
+
+// Names of the steps installed by RegisterSteps, used together with
+// WithStepExpression to change the phrasing of a single step.
+const (
+	StepCreateRequest                 = "CreateRequest"
+	StepCreatePreflightRequest        = "CreatePreflightRequest"
+	StepSetRequestHeader              = "SetRequestHeader"
+	StepSetRequestHeaders             = "SetRequestHeaders"
+	StepSetRequestQueryParameter      = "SetRequestQueryParameter"
+	StepSetRequestQueryParameters     = "SetRequestQueryParameters"
+	StepSetRequestPathParameter       = "SetRequestPathParameter"
+	StepSetRequestBodyParameter       = "SetRequestBodyParameter"
+	StepSetRequestBodyParameters      = "SetRequestBodyParameters"
+	StepSetRequestBodyParameterInt    = "SetRequestBodyParameterInt"
+	StepSetRequestBodyParameterFloat  = "SetRequestBodyParameterFloat"
+	StepSetRequestBodyStringList      = "SetRequestBodyStringList"
+	StepSetRequestBodyParameterValue  = "SetRequestBodyParameterValue"
+	StepSetRequestBody                = "SetRequestBody"
+	StepSetRequestBodyMode            = "SetRequestBodyMode"
+	StepSetRequestFormField           = "SetRequestFormField"
+	StepSetRequestFileField           = "SetRequestFileField"
+	StepSetRequestRawBody             = "SetRequestRawBody"
+	StepExecuteRequest                = "ExecuteRequest"
+	StepExecuteRequestAs              = "ExecuteRequestAs"
+	StepExecuteInvalidRequest         = "ExecuteInvalidRequest"
+	StepExecuteRequestUntilCode       = "ExecuteRequestUntilCode"
+	StepExecuteRequestUntilValue      = "ExecuteRequestUntilValue"
+	StepExecuteRequestFollowingLinks  = "ExecuteRequestFollowingLinks"
+	StepExecuteRequestFollowingCursor = "ExecuteRequestFollowingCursor"
+	StepAssertResponseCode            = "AssertResponseCode"
+	StepAssertResponseHeader          = "AssertResponseHeader"
+	StepAssertResponseHeaderMatches   = "AssertResponseHeaderMatches"
+	StepAssertResponseHeaderMissing   = "AssertResponseHeaderMissing"
+	StepAssertContentType             = "AssertContentType"
+	StepAssertResponseCookie          = "AssertResponseCookie"
+	StepAssertResponseCookieAttribute = "AssertResponseCookieAttribute"
+	StepAssertResponseCookieSameSite  = "AssertResponseCookieSameSite"
+	StepAssertResponseCookieExpires   = "AssertResponseCookieExpires"
+	StepAssertCORSAllowed             = "AssertCORSAllowed"
+	StepAssertCORSDenied              = "AssertCORSDenied"
+	StepAssertResponseValue           = "AssertResponseValue"
+	StepAssertResponseValues          = "AssertResponseValues"
+	StepAssertResponseValueMissing    = "AssertResponseValueMissing"
+	StepAssertResponseValueNotEmpty   = "AssertResponseValueNotEmpty"
+	StepAssertResponseValueCompares   = "AssertResponseValueCompares"
+	StepAssertResponseValueBetween    = "AssertResponseValueBetween"
+	StepAssertResponseValueKind       = "AssertResponseValueKind"
+	StepAssertResponseValueRecent     = "AssertResponseValueRecent"
+	StepAssertArrayLenBetween         = "AssertArrayLenBetween"
+	StepAssertElements                = "AssertElements"
+	StepAssertContains                = "AssertContains"
+	StepAssertSorted                  = "AssertSorted"
+	StepAssertUnique                  = "AssertUnique"
+	StepAssertDataLength              = "AssertDataLength"
+	StepAssertErrorMessage            = "AssertErrorMessage"
+	StepAssertError                   = "AssertError"
+	StepAssertBodyEquals              = "AssertBodyEquals"
+	StepAssertBodyEqualsIgnoring      = "AssertBodyEqualsIgnoring"
+	StepAssertSnapshot                = "AssertSnapshot"
+	StepAssertSchemaFile              = "AssertSchemaFile"
+	StepAssertSchema                  = "AssertSchema"
+	StepWithin                        = "Within"
+	StepWithinDocString               = "WithinDocString"
+	StepAssertResponseOf              = "AssertResponseOf"
+	StepAssertResponseOfDocString     = "AssertResponseOfDocString"
+	StepSetVariable                   = "SetVariable"
+	StepDeleteVariable                = "DeleteVariable"
+	StepSaveResponseMatch             = "SaveResponseMatch"
+	StepSaveResponseValue             = "SaveResponseValue"
+	StepSaveResponse                  = "SaveResponse"
+)
+
+type stepDefinition struct {
+	name string
+	expr string
+	fn   interface{}
+}
+
+type stepOptions struct {
+	prefix      string
+	expressions map[string]string
+}
+
+// StepOption changes how RegisterSteps phrases the installed steps.
+type StepOption func(*stepOptions)
+
+// WithStepPrefix inserts prefix at the start of every default step
+// expression, e.g. WithStepPrefix("the client ") turns
+// `^I execute the request$` into `^the client I execute the request$`.
+func WithStepPrefix(prefix string) StepOption {
+	return func(o *stepOptions) {
+		o.prefix = prefix
+	}
+}
+
+// WithStepExpression replaces the expression of the named step. The
+// expression is used as is, the prefix is not applied, and it must capture
+// the same arguments as the default expression.
+func WithStepExpression(name, expr string) StepOption {
+	return func(o *stepOptions) {
+		o.expressions[name] = expr
+	}
+}
+
+func (o *stepOptions) expression(step stepDefinition) string {
+	if expr, exists := o.expressions[step.name]; exists {
+		return expr
+	}
+
+	return "^" + o.prefix + strings.TrimPrefix(step.expr, "^")
+}
+
+// RegisterSteps installs the BaseFeature step vocabulary on the scenario
+// context, see the README for the list of steps. The variables and the
+// history of api are reset before every scenario and the assertion steps
+// follow the assertion mode, see SetAssertionMode.
+func RegisterSteps(sc *godog.ScenarioContext, api *BaseFeature, opts ...StepOption) {
+	options := stepOptions{
+		expressions: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	sc.Before(func(ctx context.Context, scenario *godog.Scenario) (context.Context, error) {
+		api.Variables.Reset()
+		api.ResetHistory()
+		api.SetScenario(scenario)
+		return ctx, nil
+	})
+
+	RegisterSoftAssertions(sc, api)
+
+	definitions := api.stepDefinitions()
+
+	for _, step := range definitions {
+		fn := step.fn
+		if strings.HasPrefix(step.name, "Assert") {
+			fn = api.softened(fn)
+		}
+
+		sc.Step(options.expression(step), fn)
+	}
+
+	matcher, err := newStepMatcher(definitions, options)
+	if err != nil {
+		// godog panics on invalid expressions when the steps above are added
+		panic(err)
+	}
+
+	matcher.register(sc)
+
+	within := &withinModifier{api: api, steps: matcher}
+
+	sc.Step(options.expression(stepDefinition{StepWithinDocString, `^within (\d+) seconds?, (.+:)$`, nil}), within.within)
+	sc.Step(options.expression(stepDefinition{StepWithin, `^within (\d+) seconds?, (.*[^:])$`, nil}), within.within)
+
+	// The steps on saved responses run assertions, so they follow the
+	// assertion mode as well
+	history := &historyModifier{api: api, steps: matcher}
+
+	sc.Step(options.expression(stepDefinition{StepAssertResponseOfDocString, `^the response of (\w+) (.+:)$`, nil}), api.softened(history.responseOf))
+	sc.Step(options.expression(stepDefinition{StepAssertResponseOf, `^the response of (\w+) (.*[^:])$`, nil}), api.softened(history.responseOf))
+}
+
+func (api *BaseFeature) stepDefinitions() []stepDefinition {
+	return []stepDefinition{
+		{StepCreateRequest, `^I create a "(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)" request to "([^"]*)"$`, api.CreatePathRequest},
+		{StepCreatePreflightRequest, `^I create a CORS preflight request to "([^"]*)" from "([^"]*)" for "([^"]*)"$`, api.CreatePreflightRequest},
+		{StepSetRequestHeader, `^I set the request header "([^"]*)" to "([^"]*)"$`, api.SetRequestHeaderParameterTo},
+		{StepSetRequestHeaders, `^I set the request headers:$`, api.SetRequestHeadersFromTable},
+		{StepSetRequestQueryParameter, `^I set the request query parameter "([^"]*)" to "([^"]*)"$`, api.SetRequestQueryParameterTo},
+		{StepSetRequestQueryParameters, `^I set the request query parameters:$`, api.SetRequestQueryFromTable},
+		{StepSetRequestPathParameter, `^I set the request path parameter "([^"]*)" to "([^"]*)"$`, api.SetsRequestPathParameterTo},
+		{StepSetRequestBodyParameter, `^I set the request body parameter "([^"]*)" to "([^"]*)"$`, api.SetRequestBodyParameterTo},
+		{StepSetRequestBodyParameters, `^I set the request body parameters:$`, api.SetRequestBodyFromTable},
+		{StepSetRequestBodyParameterInt, `^I set the request body parameter "([^"]*)" to the integer (-?\d+)$`, api.SetRequestBodyParameterToInt},
+		{StepSetRequestBodyParameterFloat, `^I set the request body parameter "([^"]*)" to the float (-?\d+(?:\.\d+)?)$`, api.SetRequestBodyParameterToFloat},
+		{StepSetRequestBodyStringList, `^I set the request body parameter "([^"]*)" to the list "([^"]*)"$`, api.SetRequestBodyStringListParameterTo},
+		{StepSetRequestBodyParameterValue, `^I set the request body parameter "([^"]*)" to the value (.*)$`, api.SetRequestBodyParameterToValue},
+		{StepSetRequestBody, `^I set the request body to:$`, api.SetRequestBodyFromDocString},
+		{StepSetRequestBodyMode, `^I send the request body as (json|form|multipart|raw)$`, api.SetRequestBodyMode},
+		{StepSetRequestFormField, `^I set the request form field "([^"]*)" to "([^"]*)"$`, api.SetRequestFormFieldTo},
+		{StepSetRequestFileField, `^I set the request file field "([^"]*)" to the fixture "([^"]*)"$`, api.SetRequestFileFieldTo},
+		{StepSetRequestRawBody, `^I set the raw request body to:$`, api.SetRequestRawBodyFromDocString},
+
+		{StepExecuteRequest, `^I execute the request$`, api.ExecuteTheRequest},
+		{StepExecuteRequestAs, `^I execute the request as "([^"]*)"$`, api.ExecuteTheRequestAs},
+		{StepExecuteInvalidRequest, `^I execute an invalid request$`, api.ExecuteInvalidRequest},
+		{StepExecuteRequestUntilCode, `^I execute the request until the response code is (\d+) within (\d+) seconds$`, api.executeTheRequestUntilResponseCode},
+		{StepExecuteRequestUntilValue, `^I execute the request until the response value "([^"]*)" equals "([^"]*)" within (\d+) seconds$`, api.executeTheRequestUntilResponseValue},
+
+		{StepExecuteRequestFollowingLinks, `^I execute the request collecting "([^"]*)" from every page linked by "([^"]*)"$`, api.ExecuteTheRequestFollowingNextLinks},
+		{StepExecuteRequestFollowingCursor, `^I execute the request collecting "([^"]*)" from every page using the cursor "([^"]*)" as the query parameter "([^"]*)"$`, api.ExecuteTheRequestFollowingCursor},
+
+		{StepAssertResponseCode, `^the response code should be (\d+)$`, api.AssertResponseCode},
+		{StepAssertResponseHeader, `^the response header "([^"]*)" should equal "([^"]*)"$`, api.AssertResponseHeaderEquals},
+		{StepAssertResponseHeaderMatches, `^the response header "([^"]*)" should match "([^"]*)"$`, api.AssertResponseHeaderMatches},
+		{StepAssertResponseHeaderMissing, `^the response header "([^"]*)" should be missing$`, api.AssertResponseHeaderMissing},
+		{StepAssertContentType, `^the response content type should be "([^"]*)"$`, api.AssertResponseContentType},
+		{StepAssertResponseCookie, `^the response cookie "([^"]*)" should equal "([^"]*)"$`, api.AssertResponseCookieEquals},
+		{StepAssertResponseCookieAttribute, `^the response cookie "([^"]*)" should be (secure|http only)$`, api.assertResponseCookieAttribute},
+		{StepAssertResponseCookieSameSite, `^the response cookie "([^"]*)" should have SameSite (Strict|Lax|None)$`, api.AssertResponseCookieSameSite},
+		{StepAssertResponseCookieExpires, `^the response cookie "([^"]*)" should expire within (\S+)$`, api.AssertResponseCookieExpiresWithin},
+		{StepAssertCORSAllowed, `^the response should allow CORS from "([^"]*)" for "([^"]*)"$`, api.AssertCORSAllowed},
+		{StepAssertCORSDenied, `^the response should not allow CORS from "([^"]*)"$`, api.AssertCORSDenied},
+		{StepAssertResponseValue, `^the response value "([^"]*)" should equal "([^"]*)"$`, api.AssertResponseBodyValueEquals},
+		{StepAssertResponseValues, `^the response values should be:$`, api.AssertResponseFromTable},
+		{StepAssertResponseValueMissing, `^the response value "([^"]*)" should be missing$`, api.AssertMissing},
+		{StepAssertResponseValueNotEmpty, `^the response value "([^"]*)" should not be empty$`, api.AssertNotEmpty},
+		{StepAssertResponseValueCompares, `^the response value "([^"]*)" should (not equal|be greater than|be at least|be less than|be at most|contain|start with|end with|match|be before|be after) "([^"]*)"$`, api.assertResponseValuePhrase},
+		{StepAssertResponseValueBetween, `^the response value "([^"]*)" should be between (-?\d+(?:\.\d+)?) and (-?\d+(?:\.\d+)?)$`, api.AssertResponseValueBetween},
+		{StepAssertResponseValueKind, `^the response value "([^"]*)" should be an? (null|number|string|boolean|array|object|uuid|email|url|timestamp)$`, api.AssertResponseValueIsKind},
+		{StepAssertResponseValueRecent, `^the response value "([^"]*)" should be within (\S+) of now$`, api.AssertResponseValueWithinDurationOfNow},
+		{StepAssertArrayLenBetween, `^the response value "([^"]*)" should contain between (\d+) and (\d+) items$`, api.AssertResponseArrayLenBetween},
+		{StepAssertElements, `^(some|every|no) element of "([^"]*)" should have "([^"]*)" ` + elementPhrasesExpr + ` "([^"]*)"$`, api.assertResponseElements},
+		{StepAssertContains, `^the response value "([^"]*)" should contain an element like:$`, api.AssertResponseContainsDocString},
+		{StepAssertSorted, `^the elements of "([^"]*)" should be sorted by "([^"]*)" (ascending|descending)$`, api.AssertResponseSorted},
+		{StepAssertUnique, `^every element of "([^"]*)" should have a unique "([^"]*)"$`, api.AssertResponseUnique},
+		{StepAssertDataLength, `^the response data should contain (\d+) items?$`, api.AssertDataLength},
+		{StepAssertErrorMessage, `^the response error message should be "([^"]*)"$`, api.AssertResponseBodyErrorMessageIs},
+		{StepAssertError, `^the response should be the error "([^"]*)" with code (\d+)$`, api.AssertErrorIs},
+		{StepAssertBodyEquals, `^the response body should equal:$`, api.AssertResponseBodyEqualsDocString},
+		{StepAssertBodyEqualsIgnoring, `^the response body should equal, ignoring "([^"]*)":$`, api.AssertResponseBodyEqualsIgnoringDocString},
+		{StepAssertSnapshot, `^the response should match the snapshot "([^"]*)"$`, api.AssertResponseMatchesSnapshot},
+		{StepAssertSchemaFile, `^the response should match the JSON schema "([^"]*)"$`, api.AssertResponseMatchesSchema},
+		{StepAssertSchema, `^the response should match the JSON schema:$`, api.AssertResponseMatchesSchemaDocString},
+
+		{StepSetVariable, `^I set the variable "([^"]*)" to "([^"]*)"$`, api.SetVariableTo},
+		{StepDeleteVariable, `^I delete the variable "([^"]*)"$`, api.DeleteVariable},
+		{StepSaveResponseValue, `^I save the response value at "([^"]*)" as "([^"]*)"$`, api.SaveResponseValueAs},
+		{StepSaveResponseMatch, `^I save the (index|value) of the element of "([^"]*)" having "([^"]*)" ` + elementPhrasesExpr + ` "([^"]*)" as "([^"]*)"$`, api.saveResponseMatch},
+		{StepSaveResponse, `^I save the response as "([^"]*)"$`, api.SaveExchangeAs},
+	}
+}
+
+func (api *BaseFeature) executeTheRequestUntilResponseCode(code, seconds int) error {
+	err := api.ExecuteTheRequestUntilResponse(retry.UntilResponse{
+		Condition: retry.StatusIs(code),
+		Timeout:   time.Duration(seconds) * time.Second,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "response code never became %d, last response code: %d", code, api.lastStatusCode())
+	}
+
+	return nil
+}
+
+func (api *BaseFeature) executeTheRequestUntilResponseValue(key, expected string, seconds int) (err error) {
+	if expected, err = api.value(expected); err != nil {
+		return
+	}
+
+	err = api.ExecuteTheRequestUntilResponse(retry.UntilResponse{
+		Condition: retry.JSONPathEquals(key, expected),
+		Timeout:   time.Duration(seconds) * time.Second,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "response value '%s' never became '%s', last response code: %d", key, expected, api.lastStatusCode())
+	}
+
+	return
+}
+
+func (api *BaseFeature) lastStatusCode() int {
+	if api.Response.Raw == nil {
+		return 0
+	}
+
+	return api.Response.Raw.StatusCode
+}
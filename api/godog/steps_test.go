@@ -0,0 +1,220 @@
+package godog_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+	"github.com/SKF/go-tests-utility/api/godog/openapi"
+)
+
+func runFeature(t *testing.T, feature string, initializer func(*godog.ScenarioContext)) int {
+	t.Helper()
+
+	return godog.TestSuite{
+		Name:                "steps",
+		ScenarioInitializer: initializer,
+		Options: &godog.Options{
+			Format:        "progress",
+			Output:        io.Discard,
+			Strict:        true,
+			StopOnFailure: true,
+			FeatureContents: []godog.Feature{
+				{Name: "steps.feature", Contents: []byte(feature)},
+			},
+		},
+	}.Run()
+}
+
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		if r.Body != nil && r.ContentLength > 0 {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{
+					"method": r.Method,
+					"path":   r.URL.Path,
+					"header": r.Header.Get("X-Test"),
+					"body":   body,
+				},
+			},
+		})
+		assert.NoError(t, err)
+	}))
+}
+
+const stepsFeature = `
+Feature: steps
+
+  Scenario: build, execute and assert a request
+    Given I create a "POST" request to "/nodes/{nodeId}"
+    And I set the request path parameter "nodeId" to "abc"
+    And I set the request header "X-Test" to "header value"
+    And I set the request body parameter "node.label" to "Pump 1"
+    And I set the request body parameter "node.count" to the integer 3
+    And I set the request body parameter "node.weight" to the float 1.5
+    And I set the request body parameter "node.tags" to the list "a, b"
+    When I execute the request
+    Then the response code should be 201
+    And the response value ".data[0].method" should equal "POST"
+    And the response value ".data[0].path" should equal "/nodes/abc"
+    And the response value ".data[0].header" should equal "header value"
+    And the response value ".data[0].body.node.label" should equal "Pump 1"
+    And the response value ".data[0].body.node.count" should equal "3"
+    And the response value ".data[0].body.node.tags.1" should equal "b"
+    And the response value ".data[0].missing" should be missing
+    And the response value ".data[0].method" should not be empty
+    And the response data should contain 1 item
+
+  Scenario: retry a request
+    Given I create a "GET" request to "/nodes"
+    When I execute the request until the response code is 201 within 1 seconds
+    And I execute the request until the response value ".data[0].method" equals "GET" within 1 seconds
+    Then the response code should be 201
+`
+
+func TestRegisterSteps(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	status := runFeature(t, stepsFeature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{
+			GetValue: func(key string) (string, error) {
+				return key, nil
+			},
+		}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_WithPrefixAndExpression(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := `
+Feature: steps
+
+  Scenario: custom phrasing
+    Given the client I create a "GET" request to "/nodes"
+    When the request is sent
+    Then the client the response code should be 201
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api,
+			api_godog.WithStepPrefix("the client "),
+			api_godog.WithStepExpression(api_godog.StepExecuteRequest, `^the request is sent$`),
+		)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_FailingAssertion(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := fmt.Sprintf(`
+Feature: steps
+
+  Scenario: failing assertion
+    Given I create a "GET" request to "/nodes"
+    When I execute the request
+    Then the response code should be %d
+`, http.StatusOK)
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.NotEqual(t, 0, status)
+}
+
+const untilContract = `
+openapi: 3.0.3
+info:
+  title: Nodes
+  version: 1.0.0
+paths:
+  /nodes/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: The node
+`
+
+func TestRegisterSteps_UntilWithContract(t *testing.T) {
+	var requests int32
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 404 isn't declared by the contract
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(untilContract))
+	require.NoError(t, err)
+
+	contract, err := openapi.New(doc)
+	require.NoError(t, err)
+
+	feature := `
+Feature: until
+
+  Scenario: poll until the node exists
+    Given I create a "GET" request to "/nodes/1"
+    When I execute the request until the response code is 200 within 5 seconds
+    Then the response code should be 200
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+		api.SetContract(contract)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,171 @@
+package godog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// Operators accepted by AssertResponseFromTable in addition to the
+// json_matcher.Operator values.
+const (
+	tableOpIs      = "is"
+	tableOpExists  = "exists"
+	tableOpMissing = "missing"
+)
+
+// tableRow is a row of a table with a header, cells are looked up by the
+// column names of the header.
+type tableRow struct {
+	number int
+	cells  map[string]string
+	raw    []string
+}
+
+func (r tableRow) String() string {
+	return fmt.Sprintf("row %d | %s |", r.number, strings.Join(r.raw, " | "))
+}
+
+// SetRequestBodyFromTable sets a body parameter per row of a table with the
+// columns `path`, `value` and optionally `type`, one of string (default),
+// int, float, bool, list or json, see SetRequestBodyParameterToValue for the
+// latter. Every failing row is reported.
+func (api *BaseFeature) SetRequestBodyFromTable(table *godog.Table) error {
+	return forEachRow(table, []string{"path", "value"}, func(row tableRow) error {
+		return api.setBodyParameterOfType(row.cells["path"], row.cells["value"], row.cells["type"])
+	})
+}
+
+// SetRequestHeadersFromTable adds a request header per row of a table with
+// the columns `name` and `value`.
+func (api *BaseFeature) SetRequestHeadersFromTable(table *godog.Table) error {
+	return forEachRow(table, []string{"name", "value"}, func(row tableRow) error {
+		return api.SetRequestHeaderParameterTo(row.cells["name"], row.cells["value"])
+	})
+}
+
+// SetRequestQueryFromTable adds a query parameter per row of a table with
+// the columns `name` and `value`.
+func (api *BaseFeature) SetRequestQueryFromTable(table *godog.Table) error {
+	return forEachRow(table, []string{"name", "value"}, func(row tableRow) error {
+		return api.SetRequestQueryParameterTo(row.cells["name"], row.cells["value"])
+	})
+}
+
+// AssertResponseFromTable checks a response value per row of a table with
+// the columns `path`, `operator` and `expected`. The operator is one of the
+// json_matcher.Operator values, `is` for the kinds of
+// AssertResponseValueIsKind, `exists` or `missing`, expected is resolved with
+// the `.` convention. Every failing row is reported.
+func (api *BaseFeature) AssertResponseFromTable(table *godog.Table) error {
+	return forEachRow(table, []string{"path", "operator", "expected"}, func(row tableRow) error {
+		return api.assertResponseValue(row.cells["path"], row.cells["operator"], row.cells["expected"])
+	})
+}
+
+func (api *BaseFeature) setBodyParameterOfType(path, value, kind string) (err error) {
+	if kind == "json" {
+		return api.SetRequestBodyParameterToValue(path, value)
+	}
+
+	if kind == "list" {
+		return api.SetRequestBodyStringListParameterTo(path, value)
+	}
+
+	if strings.HasPrefix(value, ".") {
+		if value, err = api.value(value); err != nil {
+			return
+		}
+	}
+
+	switch kind {
+	case "", "string":
+		return api.setBodyParameter(path, value)
+	case "int":
+		integer, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid int: %s", value)
+		}
+
+		return api.setBodyParameter(path, integer)
+	case "float":
+		float, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid float: %s", value)
+		}
+
+		return api.setBodyParameter(path, float)
+	case "bool":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid bool: %s", value)
+		}
+
+		return api.setBodyParameter(path, boolean)
+	}
+
+	return errors.Errorf("unknown type: %s", kind)
+}
+
+func (api *BaseFeature) assertResponseValue(path, op, expected string) error {
+	switch op {
+	case tableOpExists:
+		return json_matcher.KeyIsPresent(api.Response.Body, path)
+	case tableOpMissing:
+		return api.AssertMissing(path)
+	case tableOpIs:
+		return api.AssertResponseValueIsKind(path, expected)
+	}
+
+	return api.AssertResponseValueCompares(path, json_matcher.Operator(op), expected)
+}
+
+// forEachRow calls fn for every row after the header of table and returns
+// one error listing every row that failed. The header has to contain the
+// columns in required.
+func forEachRow(table *godog.Table, required []string, fn func(row tableRow) error) error {
+	if table == nil || len(table.Rows) == 0 {
+		return errors.New("Table error: Expected a table with a header row")
+	}
+
+	var columns []string
+	for _, cell := range table.Rows[0].Cells {
+		columns = append(columns, strings.TrimSpace(cell.Value))
+	}
+
+	for _, column := range required {
+		if !slices.Contains(columns, column) {
+			return errors.Errorf("Table error: Expected the columns %q got %q", required, columns)
+		}
+	}
+
+	var failures []string
+
+	for idx, tr := range table.Rows[1:] {
+		row := tableRow{number: idx + 1, cells: make(map[string]string, len(columns))}
+
+		for col, cell := range tr.Cells {
+			row.raw = append(row.raw, cell.Value)
+
+			if col < len(columns) {
+				row.cells[columns[col]] = cell.Value
+			}
+		}
+
+		if err := fn(row); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", row, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("Table error: %d of %d rows failed:\n\t%s", len(failures), len(table.Rows)-1, strings.Join(failures, "\n\t"))
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package godog_test
+
+import (
+	"testing"
+
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+func TestRegisterSteps_Tables(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	feature := `
+Feature: tables
+
+  Scenario: create a node from tables
+    Given I create a "POST" request to "/nodes"
+    And I set the variable "parent" to "site-1"
+    And I set the request headers:
+      | name   | value |
+      | X-Test | abc   |
+    And I set the request query parameters:
+      | name  | value |
+      | limit | 10    |
+    And I set the request body parameters:
+      | path        | value       | type  |
+      | name        | Pump 1      |       |
+      | parent.id   | .parent     |       |
+      | criticality | 3           | int   |
+      | speed       | 1.5         | float |
+      | active      | true        | bool  |
+      | tags        | pump, motor | list  |
+      | location    | {"x": 1}    | json  |
+    When I execute the request
+    Then the response values should be:
+      | path                         | operator    | expected |
+      | .data[0].header              | ==          | abc      |
+      | .data[0].path                | ==          | /nodes   |
+      | .data[0].body.name           | starts with | Pump     |
+      | .data[0].body.parent.id      | ==          | .parent  |
+      | .data[0].body.criticality    | >=          | 3        |
+      | .data[0].body.speed          | <           | 2        |
+      | .data[0].body.active         | is          | boolean  |
+      | .data[0].body.tags           | is          | array    |
+      | .data[0].body.location.x     | exists      |          |
+      | .data[0].body.deletedAt      | missing     |          |
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+// table builds a godog table, the first row being the header.
+func table(rows ...[]string) *godog.Table {
+	tbl := &godog.Table{}
+
+	for _, row := range rows {
+		tr := &messages.PickleTableRow{}
+		for _, cell := range row {
+			tr.Cells = append(tr.Cells, &messages.PickleTableCell{Value: cell})
+		}
+
+		tbl.Rows = append(tbl.Rows, tr)
+	}
+
+	return tbl
+}
+
+func TestAssertResponseFromTable(t *testing.T) {
+	api := &api_godog.BaseFeature{}
+	api.Response.Body = []byte(`{"data": {"name": "Pump 1", "criticality": 3}}`)
+
+	err := api.AssertResponseFromTable(table(
+		[]string{"path", "operator", "expected"},
+		[]string{".data.name", "==", "Pump 1"},
+		[]string{".data.name", "==", "Pump 2"},
+		[]string{".data.criticality", ">", "5"},
+		[]string{".data.criticality", "~", "5"},
+	))
+
+	require.EqualError(t, err, `Table error: 3 of 4 rows failed:
+	row 2 | .data.name | == | Pump 2 |: Match error: Expected 'Pump 1' to equal 'Pump 2'
+	row 3 | .data.criticality | > | 5 |: Match error: Expected 3 > 5
+	row 4 | .data.criticality | ~ | 5 |: Match error: Unknown operator '~'`)
+}
+
+func TestSetRequestBodyFromTable(t *testing.T) {
+	api := &api_godog.BaseFeature{}
+	require.NoError(t, api.CreatePathRequest("POST", "/nodes"))
+
+	err := api.SetRequestBodyFromTable(table(
+		[]string{"path", "value", "type"},
+		[]string{"count", "three", "int"},
+		[]string{"name", "Pump 1", ""},
+		[]string{"active", "yes", "bool"},
+		[]string{"size", "1", "decimal"},
+	))
+
+	require.EqualError(t, err, `Table error: 3 of 4 rows failed:
+	row 1 | count | three | int |: invalid int: three: strconv.Atoi: parsing "three": invalid syntax
+	row 3 | active | yes | bool |: invalid bool: yes: strconv.ParseBool: parsing "yes": invalid syntax
+	row 4 | size | 1 | decimal |: unknown type: decimal`)
+	require.Equal(t, "Pump 1", api.Request.Body["name"])
+
+	err = api.SetRequestBodyFromTable(table([]string{"field", "value"}))
+	require.EqualError(t, err, `Table error: Expected the columns ["path" "value"] got ["field" "value"]`)
+}
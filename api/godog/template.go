@@ -0,0 +1,256 @@
+package godog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const randomStringLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// templateFuncs are the generator functions available in request body
+// templates, e.g. `{{ uuid }}` or `{{ now | shift "-1h" | rfc3339 }}`.
+var templateFuncs = template.FuncMap{
+	"uuid": func() string {
+		return uuid.New().String()
+	},
+	"now": time.Now,
+	"shift": func(duration string, t time.Time) (time.Time, error) {
+		d, err := time.ParseDuration(duration)
+		return t.Add(d), err
+	},
+	"rfc3339": func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339)
+	},
+	"rfc3339nano": func(t time.Time) string {
+		return t.UTC().Format(time.RFC3339Nano)
+	},
+	"unix": func(t time.Time) int64 {
+		return t.Unix()
+	},
+	"unixMilli": func(t time.Time) int64 {
+		return t.UnixMilli()
+	},
+	"randomString": func(length int) string {
+		s := make([]byte, length)
+		for i := range s {
+			s[i] = randomStringLetters[rand.Intn(len(randomStringLetters))] // nolint: gosec
+		}
+
+		return string(s)
+	},
+	"randomInt": func(min, max int) int {
+		return min + rand.Intn(max-min+1) // nolint: gosec
+	},
+	"json": func(value interface{}) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+	escapeFunc: func(value interface{}) string {
+		var encoded bytes.Buffer
+
+		encoder := json.NewEncoder(&encoded)
+		encoder.SetEscapeHTML(false)
+
+		// Encoding a string can't fail
+		_ = encoder.Encode(fmt.Sprint(value))
+
+		quoted := strings.TrimSuffix(encoded.String(), "\n")
+
+		return quoted[1 : len(quoted)-1]
+	},
+}
+
+// escapeFunc is added to the actions inside JSON strings, see
+// escapeStringActions.
+const escapeFunc = "jsonStringEscape"
+
+// SetRequestBodyFromTemplate renders body as a text/template and uses the
+// resulting JSON object as the request body. Fields such as `{{ .userId }}`
+// are resolved with the `.` convention, and generator functions such as
+// uuid, now and rfc3339 can be used. The output of actions inside JSON
+// strings is escaped, e.g. `"name": "{{ .name }}"`, other actions have to
+// render JSON, e.g. `"node": {{ .node | json }}`.
+func (api *BaseFeature) SetRequestBodyFromTemplate(body string) error {
+	rendered, err := api.renderTemplate(body)
+	if err != nil {
+		return err
+	}
+
+	requestBody := make(map[string]interface{})
+	if err = json.Unmarshal(rendered, &requestBody); err != nil {
+		return errors.Wrapf(err, "request body template must render a JSON object, got: %s", rendered)
+	}
+
+	api.Request.Body = requestBody
+
+	return nil
+}
+
+func (api *BaseFeature) SetRequestBodyFromDocString(body *godog.DocString) error {
+	return api.SetRequestBodyFromTemplate(body.Content)
+}
+
+func (api *BaseFeature) renderTemplate(text string) ([]byte, error) {
+	tmpl, err := template.New("body").
+		Funcs(templateFuncs).
+		Option("missingkey=error").
+		Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse template")
+	}
+
+	escapeStringActions(tmpl.Tree)
+
+	data := make(map[string]interface{})
+	lookupErrs := make(map[string]error)
+
+	// Fields inside range and with blocks refer to other data, so fields
+	// that can't be resolved are left for template execution to report.
+	for _, name := range templateFields(tmpl.Root) {
+		if value, exists := api.Variables.Get(name); exists {
+			data[name] = value
+		} else if value, exists := api.historyTemplateValue(name); exists {
+			data[name] = value
+		} else if value, err := api.value("." + name); err == nil {
+			data[name] = value
+		} else {
+			lookupErrs[name] = err
+		}
+	}
+
+	var rendered bytes.Buffer
+	if err = tmpl.Execute(&rendered, data); err != nil {
+		for name, lookupErr := range lookupErrs {
+			if strings.Contains(err.Error(), fmt.Sprintf("no entry for key %q", name)) {
+				return nil, errors.Wrapf(lookupErr, "failed to resolve template field .%s", name)
+			}
+		}
+
+		return nil, errors.Wrap(err, "failed to execute template")
+	}
+
+	return rendered.Bytes(), nil
+}
+
+func templateFields(node parse.Node) (fields []string) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+
+		for _, n := range node.Nodes {
+			fields = append(fields, templateFields(n)...)
+		}
+	case *parse.ActionNode:
+		fields = templateFields(node.Pipe)
+	case *parse.PipeNode:
+		if node == nil {
+			return
+		}
+
+		for _, cmd := range node.Cmds {
+			fields = append(fields, templateFields(cmd)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			fields = append(fields, templateFields(arg)...)
+		}
+	case *parse.ChainNode:
+		fields = templateFields(node.Node)
+	case *parse.FieldNode:
+		fields = []string{node.Ident[0]}
+	case *parse.IfNode:
+		fields = templateBranchFields(&node.BranchNode)
+	case *parse.RangeNode:
+		fields = templateBranchFields(&node.BranchNode)
+	case *parse.WithNode:
+		fields = templateBranchFields(&node.BranchNode)
+	case *parse.TemplateNode:
+		fields = templateFields(node.Pipe)
+	}
+
+	return fields
+}
+
+func templateBranchFields(node *parse.BranchNode) (fields []string) {
+	fields = append(fields, templateFields(node.Pipe)...)
+	fields = append(fields, templateFields(node.List)...)
+	fields = append(fields, templateFields(node.ElseList)...)
+
+	return fields
+}
+
+// escapeStringActions appends escapeFunc to the actions that print inside a
+// JSON string, the way html/template escapes by context.
+func escapeStringActions(tree *parse.Tree) {
+	escapeActions(tree.Root, false)
+}
+
+// escapeActions walks node in document order and returns whether the text
+// after it is inside a JSON string.
+func escapeActions(node parse.Node, inString bool) bool {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return inString
+		}
+
+		for _, n := range node.Nodes {
+			inString = escapeActions(n, inString)
+		}
+	case *parse.TextNode:
+		inString = jsonStringState(node.Text, inString)
+	case *parse.ActionNode:
+		// Declarations don't print anything
+		if inString && len(node.Pipe.Decl) == 0 {
+			node.Pipe.Cmds = append(node.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      node.Pos,
+				Args:     []parse.Node{parse.NewIdentifier(escapeFunc).SetPos(node.Pos)},
+			})
+		}
+	case *parse.IfNode:
+		inString = escapeBranchActions(&node.BranchNode, inString)
+	case *parse.RangeNode:
+		inString = escapeBranchActions(&node.BranchNode, inString)
+	case *parse.WithNode:
+		inString = escapeBranchActions(&node.BranchNode, inString)
+	}
+
+	return inString
+}
+
+func escapeBranchActions(node *parse.BranchNode, inString bool) bool {
+	escapeActions(node.ElseList, inString)
+
+	return escapeActions(node.List, inString)
+}
+
+// jsonStringState returns whether the end of text is inside a JSON string.
+func jsonStringState(text []byte, inString bool) bool {
+	escaped := false
+
+	for _, c := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		}
+	}
+
+	return inString
+}
@@ -0,0 +1,101 @@
+package godog
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseFeature_SetRequestBodyFromTemplate(t *testing.T) {
+	api := BaseFeature{}
+	api.Variables.Set("userId", "user-1")
+	api.Variables.Set("node", map[string]interface{}{"id": "node-1", "tags": []interface{}{"a", "b"}})
+
+	err := api.CreatePathRequest(http.MethodPost, "")
+	require.NoError(t, err)
+
+	err = api.SetRequestBodyFromTemplate(`
+{
+	"userId": "{{ .userId }}",
+	"nodeId": "{{ .node.id }}",
+	"tags": [{{ range $i, $tag := .node.tags }}{{ if $i }}, {{ end }}{{ json $tag }}{{ end }}],
+	"id": "{{ uuid }}",
+	"createdAt": "{{ now | shift "-1h" | rfc3339 }}",
+	"name": "{{ randomString 8 }}"
+}`)
+	require.NoError(t, err)
+
+	body := api.Request.Body
+	assert.Equal(t, "user-1", body["userId"])
+	assert.Equal(t, "node-1", body["nodeId"])
+	assert.Equal(t, []interface{}{"a", "b"}, body["tags"])
+	assert.Len(t, body["name"], 8)
+
+	_, err = uuid.Parse(body["id"].(string))
+	assert.NoError(t, err)
+
+	createdAt, err := time.Parse(time.RFC3339, body["createdAt"].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), createdAt, time.Minute)
+}
+
+func TestBaseFeature_SetRequestBodyFromTemplate_GetValue(t *testing.T) {
+	api := BaseFeature{}
+	api.GetValue = func(key string) (string, error) {
+		return key + "fixed", nil
+	}
+
+	err := api.CreatePathRequest(http.MethodPost, "")
+	require.NoError(t, err)
+
+	err = api.SetRequestBodyFromTemplate(`{"name": "{{ .name }}"}`)
+	require.NoError(t, err)
+	assert.Equal(t, ".namefixed", api.Request.Body["name"])
+}
+
+func TestBaseFeature_SetRequestBodyFromTemplate_Escaping(t *testing.T) {
+	api := BaseFeature{}
+	api.Variables.Set("name", `Pump "A" \ 1`)
+	api.Variables.Set("node", map[string]interface{}{"name": `"quoted"`})
+	api.Variables.Set("tags", []interface{}{`a"`, `b\`})
+
+	err := api.CreatePathRequest(http.MethodPost, "")
+	require.NoError(t, err)
+
+	err = api.SetRequestBodyFromTemplate(`{
+	"name": "{{ .name }}",
+	"label": "\"{{ .name }}\"",
+	"node": {{ .node | json }},
+	"tags": [{{ range $i, $tag := .tags }}{{ if $i }}, {{ end }}"{{ $tag }}"{{ end }}]
+}`)
+	require.NoError(t, err)
+
+	body := api.Request.Body
+	assert.Equal(t, `Pump "A" \ 1`, body["name"])
+	assert.Equal(t, `"Pump "A" \ 1"`, body["label"])
+	assert.Equal(t, map[string]interface{}{"name": `"quoted"`}, body["node"])
+	assert.Equal(t, []interface{}{`a"`, `b\`}, body["tags"])
+}
+
+func TestBaseFeature_SetRequestBodyFromTemplate_Errors(t *testing.T) {
+	api := BaseFeature{}
+
+	err := api.CreatePathRequest(http.MethodPost, "")
+	require.NoError(t, err)
+
+	assert.Error(t, api.SetRequestBodyFromTemplate(`{"name": "{{ .missing }}"}`))
+	assert.Error(t, api.SetRequestBodyFromTemplate(`{"name": "{{ .name "}`))
+	assert.Error(t, api.SetRequestBodyFromTemplate(`["not", "an", "object"]`))
+
+	api.GetValue = func(key string) (string, error) {
+		return "", errors.New("lookup service unavailable")
+	}
+
+	err = api.SetRequestBodyFromTemplate(`{"name": "{{ .name }}"}`)
+	assert.EqualError(t, err, "failed to resolve template field .name: lookup service unavailable")
+}
@@ -107,3 +107,27 @@ func SetTag(ctx context.Context, key string, value interface{}) {
 		span.SetTag(key, value)
 	}
 }
+
+// SetError marks the span in ctx as failed without finishing it, e.g. for
+// failures that don't fail the step such as soft assertions.
+func SetError(ctx context.Context, err error) {
+	SetTag(ctx, dd_ext.Error, err)
+}
+
+// StartSpan starts a child of the span in ctx when tracing is enabled, e.g.
+// for every attempt of a retry. finish has to be called with the outcome of
+// the operation.
+func StartSpan(ctx context.Context, operationName, resource string) (spanCtx context.Context, finish func(err error)) {
+	if !Enabled() {
+		return ctx, func(error) {}
+	}
+
+	span, spanCtx := dd_tracer.StartSpanFromContext(ctx, operationName,
+		dd_tracer.SpanType(spanType),
+	)
+	span.SetTag(dd_ext.ResourceName, resource)
+
+	return spanCtx, func(err error) {
+		span.Finish(dd_tracer.WithError(err))
+	}
+}
@@ -5,11 +5,11 @@ import (
 )
 
 func (api *BaseFeature) AssertEquals(actual, expected string) (err error) {
-	if actual, err = api.GetValue(actual); err != nil {
+	if actual, err = api.value(actual); err != nil {
 		return
 	}
 
-	if expected, err = api.GetValue(expected); err != nil {
+	if expected, err = api.value(expected); err != nil {
 		return
 	}
 
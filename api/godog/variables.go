@@ -0,0 +1,186 @@
+package godog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	json_matcher "github.com/SKF/go-tests-utility/api/godog/json"
+)
+
+// Variables is a scenario scoped store for values referenced with the
+// `.name` convention, e.g. a header value of `.userId`. The zero value is
+// ready to use.
+type Variables struct {
+	values map[string]interface{}
+}
+
+func variableName(name string) string {
+	return strings.TrimPrefix(name, ".")
+}
+
+func (v *Variables) Set(name string, value interface{}) {
+	if v.values == nil {
+		v.values = make(map[string]interface{})
+	}
+
+	v.values[variableName(name)] = value
+}
+
+func (v *Variables) Get(name string) (value interface{}, exists bool) {
+	value, exists = v.values[variableName(name)]
+	return
+}
+
+func (v *Variables) Delete(name string) {
+	delete(v.values, variableName(name))
+}
+
+// Reset removes all variables, it is called before every scenario when the
+// steps are installed with RegisterSteps.
+func (v *Variables) Reset() {
+	v.values = nil
+}
+
+func (v *Variables) String(name string) (string, error) {
+	value, exists := v.Get(name)
+	if !exists {
+		return "", errors.Errorf("variable '%s' is not set", name)
+	}
+
+	return formatVariable(value)
+}
+
+func (v *Variables) Int(name string) (int, error) {
+	value, exists := v.Get(name)
+	if !exists {
+		return 0, errors.Errorf("variable '%s' is not set", name)
+	}
+
+	switch value := value.(type) {
+	case int:
+		return value, nil
+	case float64:
+		if value != float64(int(value)) {
+			return 0, errors.Errorf("variable '%s' is not an integer: %v", name, value)
+		}
+
+		return int(value), nil
+	case string:
+		return strconv.Atoi(value)
+	}
+
+	return 0, errors.Errorf("variable '%s' is not an integer: %v", name, value)
+}
+
+func (v *Variables) Float(name string) (float64, error) {
+	value, exists := v.Get(name)
+	if !exists {
+		return 0, errors.Errorf("variable '%s' is not set", name)
+	}
+
+	switch value := value.(type) {
+	case int:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	case string:
+		return strconv.ParseFloat(value, 64)
+	}
+
+	return 0, errors.Errorf("variable '%s' is not a number: %v", name, value)
+}
+
+func (v *Variables) Bool(name string) (bool, error) {
+	value, exists := v.Get(name)
+	if !exists {
+		return false, errors.Errorf("variable '%s' is not set", name)
+	}
+
+	switch value := value.(type) {
+	case bool:
+		return value, nil
+	case string:
+		return strconv.ParseBool(value)
+	}
+
+	return false, errors.Errorf("variable '%s' is not a boolean: %v", name, value)
+}
+
+func formatVariable(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case int, bool:
+		return fmt.Sprint(value), nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal failed")
+	}
+
+	return string(encoded), nil
+}
+
+// value resolves key using the `.` convention. Variables in the store take
+// precedence over the GetValue function, and keys without a `.` prefix are
+// returned as is when no GetValue function is set.
+func (api *BaseFeature) value(key string) (string, error) {
+	if strings.HasPrefix(key, ".") {
+		if _, exists := api.Variables.Get(key); exists {
+			return api.Variables.String(key)
+		}
+	}
+
+	if value, found, err := api.historyValue(key); found {
+		return value, err
+	}
+
+	if api.GetValue != nil {
+		return api.GetValue(key)
+	}
+
+	if strings.HasPrefix(key, ".") {
+		return "", errors.Errorf("variable '%s' is not set", key)
+	}
+
+	return key, nil
+}
+
+// SetVariableTo stores value, resolved with the `.` convention, as the
+// variable name.
+func (api *BaseFeature) SetVariableTo(name, value string) (err error) {
+	if value, err = api.value(value); err != nil {
+		return
+	}
+
+	api.Variables.Set(name, value)
+
+	return
+}
+
+func (api *BaseFeature) DeleteVariable(name string) error {
+	api.Variables.Delete(name)
+	return nil
+}
+
+// SaveResponseValueAs stores the value found at path in the response body
+// as the variable name, keeping its JSON type.
+func (api *BaseFeature) SaveResponseValueAs(path, name string) error {
+	value, err := json_matcher.ReadValue(api.Response.Body, path)
+	if err != nil {
+		return err
+	}
+
+	api.Variables.Set(name, value)
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package godog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+func TestVariables(t *testing.T) {
+	vars := api_godog.Variables{}
+
+	_, exists := vars.Get("missing")
+	assert.False(t, exists)
+
+	vars.Set("count", float64(3))
+	vars.Set(".enabled", true)
+	vars.Set("object", map[string]interface{}{"a": 1})
+
+	count, err := vars.Int(".count")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	countStr, err := vars.String("count")
+	require.NoError(t, err)
+	assert.Equal(t, "3", countStr)
+
+	enabled, err := vars.Bool("enabled")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	object, err := vars.String("object")
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, object)
+
+	_, err = vars.Int("enabled")
+	assert.Error(t, err)
+
+	vars.Delete("count")
+	_, err = vars.Float("count")
+	assert.Error(t, err)
+
+	vars.Reset()
+	_, exists = vars.Get("enabled")
+	assert.False(t, exists)
+}
+
+func TestBaseFeature_VariablesBeforeGetValue(t *testing.T) {
+	api := api_godog.BaseFeature{}
+	assert.NoError(t, api.AssertEquals("apa", "apa"))
+	assert.Error(t, api.AssertEquals(".apa", "apa"))
+
+	require.NoError(t, api.SetVariableTo("apa", "apa"))
+	assert.NoError(t, api.AssertEquals(".apa", "apa"))
+
+	api.GetValue = func(key string) (string, error) {
+		return strings.TrimPrefix(key, "."), nil
+	}
+	assert.NoError(t, api.AssertEquals(".apa", "apa"))
+	assert.NoError(t, api.AssertEquals(".bepa", "bepa"))
+}
+
+const variablesFeature = `
+Feature: variables
+
+  Scenario: feed a response value into the next request
+    Given I create a "POST" request to "/nodes"
+    And I set the request body parameter "id" to "abc"
+    And I execute the request
+    And I save the response value at ".data[0].body.id" as "nodeId"
+    When I create a "GET" request to "/nodes/{nodeId}"
+    And I set the request path parameter "nodeId" to ".nodeId"
+    And I execute the request
+    Then the response value ".data[0].path" should equal "/nodes/abc"
+    And I set the variable "path" to ".nodeId"
+    And the response value ".data[0].body.id" should be missing
+`
+
+func TestRegisterSteps_Variables(t *testing.T) {
+	s := newEchoServer(t)
+	defer s.Close()
+
+	status := runFeature(t, variablesFeature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_VariablesAreReset(t *testing.T) {
+	feature := `
+Feature: variables
+
+  Scenario: variables are reset between scenarios
+    Given I create a "GET" request to "/nodes/{nodeId}"
+    Then I set the request path parameter "nodeId" to ".nodeId"
+`
+
+	api := &api_godog.BaseFeature{}
+	api.Variables.Set("nodeId", "stale")
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.NotEqual(t, 0, status)
+
+	_, exists := api.Variables.Get("nodeId")
+	assert.False(t, exists)
+}
@@ -0,0 +1,48 @@
+package godog
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/SKF/go-tests-utility/api/godog/retry"
+)
+
+// withinInterval is how often the within step modifier re-executes the request.
+const withinInterval = 500 * time.Millisecond
+
+// withinModifier runs another registered step until it passes, re-executing
+// the last request before every new attempt, e.g.
+// `within 30 seconds, the response value ".data.state" should equal "ready"`.
+type withinModifier struct {
+	api   *BaseFeature
+	steps *stepMatcher
+}
+
+// within runs step, which gets the docstring or table of the within step.
+func (m *withinModifier) within(seconds int, step string) error {
+	call, err := m.steps.find(step)
+	if err != nil {
+		return err
+	}
+
+	first := true
+
+	err = retry.EventuallyWithContext(m.api.context(), time.Duration(seconds)*time.Second, withinInterval, func() error {
+		// The response of the preceding step is asserted before executing again
+		if !first {
+			if err := m.api.ExecuteTheRequest(); err != nil {
+				return err
+			}
+		}
+
+		first = false
+
+		return call()
+	})
+	if err != nil {
+		return errors.Wrapf(err, "step '%s' didn't pass within %d seconds", step, seconds)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package godog_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/require"
+
+	api_godog "github.com/SKF/go-tests-utility/api/godog"
+)
+
+// newEventuallyConsistentServer reports a node as pending for the first
+// two requests and as ready afterwards.
+func newEventuallyConsistentServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "pending"
+		if atomic.AddInt32(requests, 1) > 2 {
+			state = "ready"
+		}
+
+		fmt.Fprintf(w, `{"data": {"state": "%s", "tags": ["a"]}}`, state)
+	}))
+}
+
+func TestRegisterSteps_Within(t *testing.T) {
+	var requests int32
+
+	s := newEventuallyConsistentServer(&requests)
+	defer s.Close()
+
+	feature := `
+Feature: eventual consistency
+
+  Scenario: wait for a node
+    Given I create a "GET" request to "/nodes/1"
+    When I execute the request
+    Then within 5 seconds, the response value ".data.state" should equal "ready"
+    And within 1 second, the response value ".data.tags" should contain between 1 and 1 items
+    And within 1 second, the response body should equal:
+      """
+      {"data": {"state": "ready", "tags": ["a"]}}
+      """
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestRegisterSteps_WithinTimeout(t *testing.T) {
+	var requests int32
+
+	s := newEventuallyConsistentServer(&requests)
+	defer s.Close()
+
+	feature := `
+Feature: eventual consistency
+
+  Scenario: wait for a node
+    Given I create a "GET" request to "/nodes/1"
+    When I execute the request
+    Then within 1 second, the response value ".data.state" should equal "deleted"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 1, status)
+	require.GreaterOrEqual(t, atomic.LoadInt32(&requests), int32(2))
+}
+
+func TestRegisterSteps_WithinWithStepPrefix(t *testing.T) {
+	var requests int32
+
+	s := newEventuallyConsistentServer(&requests)
+	defer s.Close()
+
+	feature := `
+Feature: eventual consistency
+
+  Scenario: wait for a node
+    Given the client I create a "GET" request to "/nodes/1"
+    When the client I execute the request
+    Then the client within 5 seconds, the response value ".data.state" should equal "ready"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api, api_godog.WithStepPrefix("the client "))
+	})
+
+	require.Equal(t, 0, status)
+}
+
+func TestRegisterSteps_WithinTable(t *testing.T) {
+	var requests int32
+
+	s := newEventuallyConsistentServer(&requests)
+	defer s.Close()
+
+	feature := `
+Feature: eventual consistency
+
+  Scenario: wait for a node
+    Given I create a "GET" request to "/nodes/1"
+    When I execute the request
+    Then within 5 seconds, the response values should be:
+      | path        | operator | expected |
+      | .data.state | ==       | ready    |
+      | .data.tags  | exists   |          |
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 0, status)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestRegisterSteps_WithinZeroSeconds(t *testing.T) {
+	var requests int32
+
+	s := newEventuallyConsistentServer(&requests)
+	defer s.Close()
+
+	feature := `
+Feature: eventual consistency
+
+  Scenario: wait for a node
+    Given I create a "GET" request to "/nodes/1"
+    When I execute the request
+    Then within 0 seconds, the response value ".data.state" should equal "ready"
+`
+
+	status := runFeature(t, feature, func(sc *godog.ScenarioContext) {
+		api := &api_godog.BaseFeature{}
+		api.SetBaseUrl(s.URL)
+
+		api_godog.RegisterSteps(sc, api)
+	})
+
+	require.Equal(t, 1, status)
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}